@@ -14,6 +14,8 @@ func solveSudoku(game [][]int) (err error) {
 
 	config := z3.NewConfig()
 	ctx := z3.NewContext(config)
+	config.Close()
+	defer ctx.Close()
 
 	for r := 0; r < 9; r++ {
 		vars[r] = make([]*z3.Expr, 9)
@@ -72,6 +74,7 @@ func solveSudoku(game [][]int) (err error) {
 	}
 
 	solver := z3.NewSolver(ctx)
+	defer solver.Close()
 	err = solver.Add(conds...)
 	if err != nil {
 		return
@@ -85,9 +88,14 @@ func solveSudoku(game [][]int) (err error) {
 		return
 	}
 	model := solver.GetModel()
+	defer model.Close()
 	for r := 0; r < 9; r++ {
 		for c := 0; c < 9; c++ {
-			fmt.Print(model.Eval(vars[r][c], false), " ")
+			var value int64
+			if value, err = model.Eval(vars[r][c], true).Int64(); err != nil {
+				return
+			}
+			fmt.Print(value, " ")
 		}
 		fmt.Println()
 	}
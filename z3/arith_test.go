@@ -0,0 +1,89 @@
+package z3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func checkModel(t *testing.T, ctx *Context, constraints []*Expr, vars []*Expr, expected []string) {
+	solver := NewSolver(ctx)
+	defer solver.Close()
+	if err := solver.Add(constraints...); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+	defer model.Close()
+	for i, v := range vars {
+		if value := model.Eval(v, true).String(); value != expected[i] {
+			t.Error("Expected", v, "=", expected[i], "got", value)
+		}
+	}
+}
+
+func TestIntArithmetic(t *testing.T) {
+	ctx := getContext()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	checkModel(t, ctx, []*Expr{
+		Eq(Add(x, y, ctx.IntVal(1)), ctx.IntVal(8)),
+		Eq(Mul(x, y), ctx.IntVal(12)),
+		Gt(Sub(x, y), ctx.IntVal(0)),
+		Eq(Mod(x, ctx.IntVal(3)), ctx.IntVal(1)),
+		Eq(Rem(Neg(x), ctx.IntVal(2)), ctx.IntVal(0)),
+	}, []*Expr{x, y, Div(x, y)}, []string{"4", "3", "1"})
+}
+
+func TestIntRealConversion(t *testing.T) {
+	ctx := getContext()
+	x := ctx.IntConst("x")
+	if expr := IsInt(ToReal(x)); expr == nil || expr.Sort().SortKind() != BoolSort {
+		t.Error("Expected boolean expression, got", expr)
+	}
+	if expr := ToInt(ToReal(x)); expr == nil || expr.Sort().SortKind() != IntSort {
+		t.Error("Expected integer expression, got", expr)
+	}
+	if expr := Power(x, ctx.IntVal(2)); expr == nil {
+		t.Error("Expected valid expression, got nil:", ctx.LastError)
+	}
+	if expr := Add(x, ctx.BoolConst("b")); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+}
+
+func TestRealNumerals(t *testing.T) {
+	ctx := getContext()
+	x, y := ctx.RealConst("x"), ctx.RealConst("y")
+	third := big.NewRat(1, 3)
+	huge := new(big.Int).Lsh(big.NewInt(1), 100)
+	solver := NewSolver(ctx)
+	solver.Add(
+		Eq(Mul(x, ctx.Int64Val(3)), ToReal(ctx.Uint64Val(1))),
+		Eq(y, Add(ctx.RealVal(third), ctx.Numeral("0.25", ctx.RealSort()))))
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+
+	tests := []struct {
+		expr     *Expr
+		expected *big.Rat
+	}{
+		{x, third},
+		{y, big.NewRat(7, 12)},
+		{ctx.BigIntVal(huge), new(big.Rat).SetInt(huge)},
+		{ctx.Numeral("-3/4", ctx.RealSort()), big.NewRat(-3, 4)},
+	}
+	for _, test := range tests {
+		value, err := model.Eval(test.expr, true).Rat()
+		if err != nil {
+			t.Error("Unexpected error:", err)
+		} else if value.Cmp(test.expected) != 0 {
+			t.Error("Expected", test.expected, "got", value)
+		}
+	}
+	if _, err := x.Rat(); err == nil {
+		t.Error("Expected error for non-numeral")
+	}
+}
@@ -0,0 +1,62 @@
+package z3
+
+import "testing"
+
+func TestArraySelectStore(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	a := ctx.Constant("a", ctx.ArraySort(intSort, intSort))
+	zeros := ConstArray(intSort, ctx.IntVal(0))
+	b := Store(zeros, ctx.IntVal(1), ctx.IntVal(10))
+	negate := AsArray(Neg(ctx.IntConst("x")).Decl())
+	checkModel(t, ctx, []*Expr{
+		Eq(a, Map(Add(ctx.IntConst("x"), ctx.IntConst("y")).Decl(), b, b)),
+	}, []*Expr{
+		Select(a, ctx.IntVal(1)),
+		Select(a, ctx.IntVal(2)),
+		Select(negate, ctx.IntVal(5)),
+	}, []string{"20", "0", "(- 5)"})
+	if !negate.IsAsArray() || negate.AsArrayFuncDecl() == nil {
+		t.Error("Expected as-array term, got", negate)
+	}
+}
+
+func TestArrayMultiIndex(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	sort := ctx.ArraySortN([]*Sort{intSort, intSort}, ctx.BoolSort())
+	if sort == nil {
+		t.Fatal("Expected valid sort, got nil:", ctx.LastError)
+	}
+	m := ctx.Constant("m", sort)
+	i, j := ctx.IntConst("i"), ctx.IntConst("j")
+	checkModel(t, ctx, []*Expr{
+		Eq(StoreN(m, []*Expr{i, j}, ctx.BoolVal(true)), m),
+		Eq(i, ctx.IntVal(3)),
+	}, []*Expr{
+		SelectN(m, ctx.IntVal(3), j),
+	}, []string{"true"})
+
+	if expr := SelectN(m); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+}
+
+func TestArrayExt(t *testing.T) {
+	ctx := getContext()
+	sort := ctx.ArraySort(ctx.IntSort(), ctx.IntSort())
+	a, b := ctx.Constant("a", sort), ctx.Constant("b", sort)
+	solver := NewSolver(ctx)
+	k := ArrayExt(a, b)
+	solver.Add(Not(Eq(a, b)), Eq(Select(a, k), Select(b, k)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+
+	solver.Reset()
+	solver.Add(Not(Eq(ArrayDefault(ConstArray(ctx.IntSort(), ctx.IntVal(7))), ctx.IntVal(7))))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+}
@@ -0,0 +1,90 @@
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestASTFinalizer(t *testing.T) {
+	ctx := getContext()
+	for i := 0; i < 1000; i++ {
+		ctx.IntConst(fmt.Sprintf("x%d", i))
+	}
+	runtime.GC()
+
+	// Building new ASTs releases the ones collected in the meantime.
+	x := ctx.IntConst("x0")
+	solver := NewSolver(ctx)
+	if err := solver.Add(Eq(x, ctx.IntVal(42))); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+}
+
+// deletions holds a channel for each watched Z3 context, closed once the
+// context is deleted.
+var deletions = struct {
+	sync.Mutex
+	watched map[interface{}]chan struct{}
+}{watched: map[interface{}]chan struct{}{}}
+
+func init() {
+	contextDeleted = func(ctx *Context) {
+		deletions.Lock()
+		defer deletions.Unlock()
+		if deleted, ok := deletions.watched[ctx.z3val]; ok {
+			close(deleted)
+			delete(deletions.watched, ctx.z3val)
+		}
+	}
+}
+
+// watchDeletion returns a channel that is closed once ctx is deleted.
+func watchDeletion(ctx *Context) <-chan struct{} {
+	deletions.Lock()
+	defer deletions.Unlock()
+	deleted := make(chan struct{})
+	deletions.watched[ctx.z3val] = deleted
+	return deleted
+}
+
+// waitForDeletion runs the garbage collector until the watched contexts are
+// deleted, or fails the test.
+func waitForDeletion(t *testing.T, watches ...<-chan struct{}) {
+	for i, deleted := range watches {
+		timeout := time.After(time.Second)
+	wait:
+		for {
+			runtime.GC()
+			select {
+			case <-deleted:
+				break wait
+			case <-timeout:
+				t.Error("Context", i, "was not deleted")
+				break wait
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+}
+
+// dropContexts creates contexts with live ASTs and drops them without closing
+// them.
+func dropContexts(n int) []<-chan struct{} {
+	var watches []<-chan struct{}
+	for i := 0; i < n; i++ {
+		ctx := getContext()
+		watches = append(watches, watchDeletion(ctx))
+		Gt(ctx.IntConst("x"), ctx.IntVal(i))
+	}
+	return watches
+}
+
+func TestContextFinalizer(t *testing.T) {
+	waitForDeletion(t, dropContexts(5)...)
+}
@@ -0,0 +1,651 @@
+package z3
+
+// #include <z3.h>
+import "C"
+
+// -----------------------------------------------------------------------------
+// Bit-vector operations
+//
+// All operands of a bit-vector operation must have the same size, unless
+// stated otherwise.
+
+// Arithmetic
+
+// BVAdd returns the two's complement sum of a and b.
+func BVAdd(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvadd(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSub returns the two's complement difference of a and b.
+func BVSub(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsub(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVMul returns the two's complement product of a and b.
+func BVMul(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvmul(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVUDiv returns the unsigned quotient of a and b.
+func BVUDiv(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvudiv(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSDiv returns the signed quotient of a and b, rounded towards zero.
+func BVSDiv(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsdiv(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVURem returns the unsigned remainder of a divided by b.
+func BVURem(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvurem(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSRem returns the signed remainder of a divided by b, whose sign follows
+// the dividend.
+func BVSRem(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsrem(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSMod returns the signed remainder of a divided by b, whose sign follows
+// the divisor.
+func BVSMod(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsmod(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVNeg returns the two's complement negation of a.
+func BVNeg(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvneg(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Bitwise operations
+
+// BVAnd returns the bitwise and of a and b.
+func BVAnd(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvand(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVOr returns the bitwise or of a and b.
+func BVOr(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvor(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVXor returns the bitwise exclusive or of a and b.
+func BVXor(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvxor(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVNand returns the bitwise nand of a and b.
+func BVNand(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvnand(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVNor returns the bitwise nor of a and b.
+func BVNor(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvnor(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVXnor returns the bitwise xnor of a and b.
+func BVXnor(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvxnor(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVNot returns the bitwise negation of a.
+func BVNot(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvnot(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVRedAnd returns the conjunction of the bits of a, as a bit-vector of size 1.
+func BVRedAnd(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvredand(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVRedOr returns the disjunction of the bits of a, as a bit-vector of size 1.
+func BVRedOr(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvredor(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Shifts and rotations
+
+// BVShl shifts a left by b bits.
+func BVShl(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvshl(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVLShr shifts a right by b bits, filling in zeros.
+func BVLShr(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvlshr(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVAShr shifts a right by b bits, replicating the sign bit.
+func BVAShr(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvashr(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVRotateLeft rotates the bits of a to the left i times.
+func BVRotateLeft(i uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_rotate_left(ctx.z3val, C.uint(i), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVRotateRight rotates the bits of a to the right i times.
+func BVRotateRight(i uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_rotate_right(ctx.z3val, C.uint(i), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVExtRotateLeft rotates the bits of a to the left b times.
+func BVExtRotateLeft(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_ext_rotate_left(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVExtRotateRight rotates the bits of a to the right b times.
+func BVExtRotateRight(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_ext_rotate_right(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Comparisons
+
+// BVULt checks whether a < b, as unsigned integers.
+func BVULt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvult(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVULe checks whether a <= b, as unsigned integers.
+func BVULe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvule(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVUGt checks whether a > b, as unsigned integers.
+func BVUGt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvugt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVUGe checks whether a >= b, as unsigned integers.
+func BVUGe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvuge(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSLt checks whether a < b, as signed integers.
+func BVSLt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvslt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSLe checks whether a <= b, as signed integers.
+func BVSLe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsle(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSGt checks whether a > b, as signed integers.
+func BVSGt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsgt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSGe checks whether a >= b, as signed integers.
+func BVSGe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsge(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Size-changing operations
+
+// BVConcat concatenates a and b, which may have different sizes. The bits of a
+// become the most significant bits of the result.
+func BVConcat(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_concat(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVExtract returns the bits of a between high and low, inclusive.
+func BVExtract(high, low uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_extract(ctx.z3val, C.uint(high), C.uint(low), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVZeroExt extends a with i zero bits.
+func BVZeroExt(i uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_zero_ext(ctx.z3val, C.uint(i), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSignExt extends a with i copies of its sign bit.
+func BVSignExt(i uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_sign_ext(ctx.z3val, C.uint(i), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVRepeat concatenates i copies of a.
+func BVRepeat(i uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_repeat(ctx.z3val, C.uint(i), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BV2Int converts the bit-vector a to an integer, interpreting it as signed
+// or unsigned.
+func BV2Int(a *Expr, signed bool) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bv2int(ctx.z3val, a.z3val, getZ3Bool(signed)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Int2BV converts the integer a to a bit-vector of the given size.
+func Int2BV(size uint, a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_int2bv(ctx.z3val, C.uint(size), a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Overflow checks
+
+// BVAddNoOverflow checks that a + b does not overflow.
+func BVAddNoOverflow(a, b *Expr, signed bool) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvadd_no_overflow(ctx.z3val, a.z3val, b.z3val, getZ3Bool(signed)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVAddNoUnderflow checks that the signed sum a + b does not underflow.
+func BVAddNoUnderflow(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvadd_no_underflow(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSubNoOverflow checks that the signed difference a - b does not overflow.
+func BVSubNoOverflow(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsub_no_overflow(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSubNoUnderflow checks that a - b does not underflow.
+func BVSubNoUnderflow(a, b *Expr, signed bool) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsub_no_underflow(ctx.z3val, a.z3val, b.z3val, getZ3Bool(signed)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVSDivNoOverflow checks that the signed quotient a / b does not overflow.
+func BVSDivNoOverflow(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvsdiv_no_overflow(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVNegNoOverflow checks that the negation of a, as a signed integer, does
+// not overflow.
+func BVNegNoOverflow(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvneg_no_overflow(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVMulNoOverflow checks that a * b does not overflow.
+func BVMulNoOverflow(a, b *Expr, signed bool) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvmul_no_overflow(ctx.z3val, a.z3val, b.z3val, getZ3Bool(signed)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// BVMulNoUnderflow checks that the signed product a * b does not underflow.
+func BVMulNoUnderflow(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bvmul_no_underflow(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
@@ -0,0 +1,35 @@
+package z3
+
+import "testing"
+
+func TestBVOperations(t *testing.T) {
+	ctx := getContext()
+	x := ctx.BVConst("x", 8)
+	checkModel(t, ctx, []*Expr{
+		Eq(BVAdd(x, ctx.BVUintVal(1, 8)), ctx.BVUintVal(0, 8)),
+	}, []*Expr{
+		x,
+		BVLShr(x, ctx.BVUintVal(4, 8)),
+		BVAShr(BVShl(x, ctx.BVUintVal(4, 8)), ctx.BVUintVal(2, 8)),
+		BVExtract(3, 0, BVXor(x, ctx.BVUintVal(0x5a, 8))),
+		BVConcat(BVRedAnd(x), BVNot(x)),
+		BV2Int(x, true),
+		BV2Int(BVZeroExt(8, x), false),
+	}, []string{"#xff", "#x0f", "#xfc", "#x5", "#b100000000", "(- 1)", "255"})
+}
+
+func TestBVOverflow(t *testing.T) {
+	ctx := getContext()
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	solver := NewSolver(ctx)
+	solver.Add(
+		BVULe(x, ctx.BVUintVal(15, 8)),
+		BVULe(y, ctx.BVUintVal(15, 8)),
+		Not(BVMulNoOverflow(x, y, false)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+	if expr := BVAdd(x, ctx.BVConst("z", 16)); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+}
@@ -0,0 +1,69 @@
+package z3
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// addPigeonhole asserts that n+1 pigeons fit in n holes, which is
+// unsatisfiable but takes exponential time for resolution-based solvers.
+func addPigeonhole(ctx *Context, solver *Solver, n int) {
+	in := make([][]*Expr, n+1)
+	for p := range in {
+		in[p] = make([]*Expr, n)
+		for h := range in[p] {
+			in[p][h] = ctx.FreshConstant("in", ctx.BoolSort())
+		}
+		solver.Add(Or(in[p]...))
+	}
+	for h := 0; h < n; h++ {
+		for p := 0; p <= n; p++ {
+			for q := p + 1; q <= n; q++ {
+				solver.Add(Or(Not(in[p][h]), Not(in[q][h])))
+			}
+		}
+	}
+}
+
+func TestCheckContextTimeout(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	solver.Push()
+	addPigeonhole(ctx, solver, 14)
+
+	goCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := solver.CheckContext(goCtx)
+	if result != LUndef || !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Expected", LUndef, ErrTimeout, "got", result, err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Error("Check was not interrupted promptly, took", elapsed)
+	}
+
+	// The solver remains usable.
+	solver.Pop(1)
+	solver.Add(ctx.BoolConst("a"))
+	if result, err := solver.CheckContext(context.Background()); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+}
+
+func TestCheckContextCancel(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	addPigeonhole(ctx, solver, 14)
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	if result, err := solver.CheckContext(goCtx); result != LUndef || !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Error("Expected", LUndef, ErrCanceled, "got", result, err)
+	}
+	// An already canceled context does not start a check.
+	if result, err := solver.CheckContext(goCtx); result != LUndef || !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Error("Expected", LUndef, ErrCanceled, "got", result, err)
+	}
+}
@@ -0,0 +1,107 @@
+package z3
+
+import "testing"
+
+func expectUsageError(t *testing.T, err error) {
+	if e, ok := err.(*Error); !ok || e.Code != InvalidUsage {
+		t.Error("Expected", InvalidUsage, "error, got", err)
+	}
+}
+
+func TestConfigClose(t *testing.T) {
+	config := NewConfig()
+	if err := config.SetParamBool("model", true); err != nil {
+		t.Error("Unexpected error:", err)
+	}
+	config.Close()
+	config.Close()
+
+	expectUsageError(t, config.SetParamBool("model", true))
+	if ctx := NewContext(config); ctx != nil {
+		t.Error("Expected nil context for closed config")
+	}
+}
+
+func TestSolverClose(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	solver.Close()
+	solver.Close()
+
+	_, err := solver.Check()
+	expectUsageError(t, err)
+	expectUsageError(t, solver.Add(ctx.BoolVal(true)))
+	if model := solver.GetModel(); model != nil {
+		t.Error("Expected nil model for closed solver")
+	}
+}
+
+// closeContextEarly closes a context while objects created from it are still
+// in use, and returns once they have all been closed or dropped.
+func closeContextEarly(t *testing.T) <-chan struct{} {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	solver := NewSolver(ctx)
+	x := ctx.IntConst("x")
+	eq := Eq(x, x)
+	sym := ctx.NewStringSymbol("s")
+	ctx.Close()
+	ctx.Close()
+
+	if sort := ctx.IntSort(); sort != nil {
+		t.Error("Expected nil sort for closed context")
+	}
+	expectUsageError(t, ctx.LastError)
+	if sum := Add(x, x); sum != nil {
+		t.Error("Expected nil expression for closed context")
+	}
+	expectUsageError(t, ctx.LastError)
+	for _, empty := range []func(...*Expr) *Expr{ctx.And, ctx.Or, ctx.Distinct} {
+		ctx.LastError = nil
+		if expr := empty(); expr != nil {
+			t.Error("Expected nil expression for closed context, got", expr)
+		}
+		expectUsageError(t, ctx.LastError)
+	}
+	ctx.LastError = nil
+	if names := ctx.TacticNames(); names != nil {
+		t.Error("Expected no tactic names for closed context")
+	}
+	expectUsageError(t, ctx.LastError)
+	ctx.LastError = nil
+	if names := ctx.ProbeNames(); names != nil {
+		t.Error("Expected no probe names for closed context")
+	}
+	expectUsageError(t, ctx.LastError)
+	ctx.LastError = nil
+	if name := sym.String(); name != "" {
+		t.Error("Expected empty symbol name for closed context, got", name)
+	}
+	expectUsageError(t, ctx.LastError)
+	if name := x.Sort().Name(); name != "Int" {
+		t.Error("Expected sort name Int after Close, got", name)
+	}
+
+	// Objects created before Close remain usable.
+	if err := solver.Add(eq); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+	solver.Close()
+	if ctx.deleted {
+		t.Error("Context deleted while a model is still alive")
+	}
+	model.Close()
+	if ctx.deleted {
+		t.Error("Context deleted while ASTs are still alive")
+	}
+	return deleted
+}
+
+func TestContextCloseOrdering(t *testing.T) {
+	// The ASTs dropped after Close are released once the context is collected.
+	waitForDeletion(t, closeContextEarly(t))
+}
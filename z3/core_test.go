@@ -0,0 +1,64 @@
+package z3
+
+import (
+	"sort"
+	"testing"
+)
+
+func coreNames(core []*Expr) []string {
+	names := make([]string, len(core))
+	for i, e := range core {
+		names[i] = e.String()
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCheckAssumptions(t *testing.T) {
+	ctx := getContext()
+	x := ctx.IntConst("x")
+	p, q, r := ctx.BoolConst("p"), ctx.BoolConst("q"), ctx.BoolConst("r")
+	solver := NewSolver(ctx)
+	solver.Add(Implies(p, Gt(x, ctx.IntVal(10))), Implies(q, Lt(x, ctx.IntVal(5))), Implies(r, Eq(x, ctx.IntVal(7))))
+
+	if result, err := solver.CheckAssumptions(p, Not(r)); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	if result, err := solver.CheckAssumptions(p, q); result != LFalse || err != nil {
+		t.Fatal("Expected", LFalse, "got", result, err)
+	}
+	if core := coreNames(solver.UnsatCore()); len(core) != 2 || core[0] != "p" || core[1] != "q" {
+		t.Error("Expected core [p q], got", core)
+	}
+	// Assumptions do not persist beyond the check.
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+}
+
+func TestAssertAndTrack(t *testing.T) {
+	ctx := getContext()
+	cores, threads := ctx.IntConst("cores"), ctx.IntConst("threads")
+	solver := NewSolver(ctx)
+	params := NewParams(ctx)
+	params.SetBool("core.minimize", true)
+	if err := solver.SetParams(params); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	solver.AssertAndTrack(Le(cores, ctx.IntVal(4)), ctx.BoolConst("max-cores"))
+	solver.AssertAndTrack(Ge(threads, ctx.IntVal(2)), ctx.BoolConst("min-threads"))
+	solver.AssertAndTrack(Eq(threads, Mul(cores, ctx.IntVal(4))), ctx.BoolConst("threads-per-core"))
+	solver.AssertAndTrack(Ge(threads, ctx.IntVal(32)), ctx.BoolConst("min-throughput"))
+
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Fatal("Expected", LFalse, "got", result, err)
+	}
+	core := coreNames(solver.UnsatCore())
+	if len(core) != 3 || core[0] != "max-cores" || core[1] != "min-throughput" || core[2] != "threads-per-core" {
+		t.Error("Expected minimal core, got", core)
+	}
+
+	if err := solver.AssertAndTrack(ctx.BoolVal(true), nil); err == nil {
+		t.Error("Expected error for nil label")
+	}
+}
@@ -0,0 +1,257 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import "fmt"
+
+// -----------------------------------------------------------------------------
+// Algebraic datatypes
+
+// Field describes an accessor of a datatype constructor. Fields referring to
+// one of the datatypes being declared set Recursive, leave Sort nil and set
+// SortRef to the index of that datatype in the declaration.
+type Field struct {
+	Name      string
+	Sort      *Sort
+	Recursive bool
+	SortRef   uint
+}
+
+// Constructor describes a datatype constructor. The recognizer name defaults
+// to "is-" followed by the constructor name.
+type Constructor struct {
+	Name       string
+	Recognizer string
+	Fields     []Field
+}
+
+// DatatypeDecl describes one of a group of mutually recursive datatypes.
+type DatatypeDecl struct {
+	Name         string
+	Constructors []Constructor
+}
+
+// Datatype declares a possibly recursive datatype with the given constructors.
+// Its constructor, recognizer and accessor functions are available from the
+// returned sort.
+func (ctx *Context) Datatype(name string, constructors ...Constructor) *Sort {
+	sorts := ctx.Datatypes(DatatypeDecl{name, constructors})
+	if sorts == nil {
+		return nil
+	}
+	return sorts[0]
+}
+
+// Datatypes declares a group of mutually recursive datatypes, returning their
+// sorts in the order of the declarations.
+func (ctx *Context) Datatypes(decls ...DatatypeDecl) []*Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if len(decls) == 0 {
+		ctx.setError(&Error{InvalidArg, "no datatypes to declare"})
+		return nil
+	}
+	var constructors []C.Z3_constructor
+	lists := make([]C.Z3_constructor_list, 0, len(decls))
+	defer func() {
+		for _, list := range lists {
+			C.Z3_del_constructor_list(ctx.z3val, list)
+		}
+		for _, constructor := range constructors {
+			C.Z3_del_constructor(ctx.z3val, constructor)
+		}
+	}()
+
+	names := make([]C.Z3_symbol, len(decls))
+	for i, decl := range decls {
+		if len(decl.Constructors) == 0 {
+			ctx.setError(&Error{InvalidArg, fmt.Sprintf("datatype %s has no constructors", decl.Name)})
+			return nil
+		}
+		names[i] = ctx.NewStringSymbol(decl.Name).z3val
+		first := len(constructors)
+		for _, constructor := range decl.Constructors {
+			z3constructor := ctx.newConstructor(constructor, len(decls))
+			if z3constructor == nil {
+				return nil
+			}
+			constructors = append(constructors, z3constructor)
+		}
+		lists = append(lists, C.Z3_mk_constructor_list(ctx.z3val,
+			C.uint(len(decl.Constructors)), &constructors[first]))
+	}
+
+	z3sorts := make([]C.Z3_sort, len(decls))
+	C.Z3_mk_datatypes(ctx.z3val, C.uint(len(decls)), &names[0], &z3sorts[0], &lists[0])
+	if err := ctx.getError(); err != nil {
+		return nil
+	}
+	sorts := make([]*Sort, len(z3sorts))
+	for i, z3sort := range z3sorts {
+		sorts[i] = ctx.newSort(z3sort)
+	}
+	return sorts
+}
+
+// newConstructor builds a constructor of one of n datatypes being declared.
+func (ctx *Context) newConstructor(constructor Constructor, n int) C.Z3_constructor {
+	recognizer := constructor.Recognizer
+	if recognizer == "" {
+		recognizer = "is-" + constructor.Name
+	}
+	fields := len(constructor.Fields)
+	fieldNames := make([]C.Z3_symbol, fields)
+	fieldSorts := make([]C.Z3_sort, fields)
+	sortRefs := make([]C.uint, fields)
+	for i, field := range constructor.Fields {
+		switch {
+		case field.Recursive && field.Sort != nil:
+			ctx.setError(&Error{InvalidArg, fmt.Sprintf("recursive field %s of %s has a sort", field.Name, constructor.Name)})
+			return nil
+		case field.Recursive && field.SortRef >= uint(n):
+			ctx.setError(&Error{InvalidArg, fmt.Sprintf("field %s of %s refers to datatype %d of %d", field.Name, constructor.Name, field.SortRef, n)})
+			return nil
+		case field.Recursive:
+			sortRefs[i] = C.uint(field.SortRef)
+		case field.Sort == nil:
+			ctx.setError(&Error{InvalidArg, fmt.Sprintf("field %s of %s has no sort", field.Name, constructor.Name)})
+			return nil
+		default:
+			if err := ctx.checkSorts(field.Sort); err != nil {
+				return nil
+			}
+			fieldSorts[i] = field.Sort.z3sort()
+		}
+		fieldNames[i] = ctx.NewStringSymbol(field.Name).z3val
+	}
+	var namesPtr *C.Z3_symbol
+	var refsPtr *C.uint
+	if fields > 0 {
+		namesPtr, refsPtr = &fieldNames[0], &sortRefs[0]
+	}
+	z3constructor, err := C.Z3_mk_constructor(ctx.z3val,
+		ctx.NewStringSymbol(constructor.Name).z3val, ctx.NewStringSymbol(recognizer).z3val,
+		C.uint(fields), namesPtr, sortsPtr(fieldSorts), refsPtr), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return z3constructor
+}
+
+// EnumSort declares an enumeration sort with the given values. The constructor
+// of each value is a constant, so the values are obtained with
+// sort.Constructor(i).Apply().
+func (ctx *Context) EnumSort(name string, values ...string) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if len(values) == 0 {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf("enumeration %s has no values", name)})
+		return nil
+	}
+	symbols := make([]C.Z3_symbol, len(values))
+	for i, value := range values {
+		symbols[i] = ctx.NewStringSymbol(value).z3val
+	}
+	consts := make([]C.Z3_func_decl, len(values))
+	testers := make([]C.Z3_func_decl, len(values))
+	z3sort, err := C.Z3_mk_enumeration_sort(ctx.z3val, ctx.NewStringSymbol(name).z3val,
+		C.uint(len(values)), &symbols[0], &consts[0], &testers[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// TupleSort declares a record sort with a single constructor, named after the
+// sort, and one accessor per field.
+func (ctx *Context) TupleSort(name string, fieldNames []string, fieldSorts []*Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(fieldSorts...); err != nil {
+		return nil
+	}
+	if len(fieldNames) != len(fieldSorts) {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf(
+			"tuple %s needs matching field names and sorts, got %d and %d", name, len(fieldNames), len(fieldSorts))})
+		return nil
+	}
+	symbols := make([]C.Z3_symbol, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		symbols[i] = ctx.NewStringSymbol(fieldName).z3val
+	}
+	z3sorts := extractSorts(fieldSorts)
+	// One extra slot, so that &projs[0] is valid for tuples without fields.
+	projs := make([]C.Z3_func_decl, len(fieldNames)+1)
+	var mkDecl C.Z3_func_decl
+	var symbolsPtr *C.Z3_symbol
+	if len(symbols) > 0 {
+		symbolsPtr = &symbols[0]
+	}
+	z3sort, err := C.Z3_mk_tuple_sort(ctx.z3val, ctx.NewStringSymbol(name).z3val,
+		C.uint(len(symbols)), symbolsPtr, sortsPtr(z3sorts), &mkDecl, &projs[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// ListSort declares a sort of lists of elements of the given sort. Its
+// constructors are nil and cons, the latter having the accessors head and
+// tail.
+func (ctx *Context) ListSort(name string, elem *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(elem); err != nil {
+		return nil
+	}
+	var nilDecl, isNilDecl, consDecl, isConsDecl, headDecl, tailDecl C.Z3_func_decl
+	z3sort, err := C.Z3_mk_list_sort(ctx.z3val, ctx.NewStringSymbol(name).z3val, elem.z3sort(),
+		&nilDecl, &isNilDecl, &consDecl, &isConsDecl, &headDecl, &tailDecl), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// NumConstructors returns the number of constructors of a datatype sort.
+func (sort *Sort) NumConstructors() uint {
+	n, err := C.Z3_get_datatype_sort_num_constructors(sort.ctx.z3val, sort.z3sort()), sort.ctx.getError()
+	if err != nil {
+		return 0
+	}
+	return uint(n)
+}
+
+// Constructor returns the i-th constructor of a datatype sort.
+func (sort *Sort) Constructor(i uint) *FuncDecl {
+	z3decl, err := C.Z3_get_datatype_sort_constructor(sort.ctx.z3val, sort.z3sort(), C.uint(i)), sort.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return sort.ctx.newFuncDecl(z3decl)
+}
+
+// Recognizer returns the predicate checking whether a value of a datatype sort
+// was built with the i-th constructor.
+func (sort *Sort) Recognizer(i uint) *FuncDecl {
+	z3decl, err := C.Z3_get_datatype_sort_recognizer(sort.ctx.z3val, sort.z3sort(), C.uint(i)), sort.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return sort.ctx.newFuncDecl(z3decl)
+}
+
+// Accessor returns the j-th field accessor of the i-th constructor of a
+// datatype sort.
+func (sort *Sort) Accessor(i, j uint) *FuncDecl {
+	z3decl, err := C.Z3_get_datatype_sort_constructor_accessor(sort.ctx.z3val, sort.z3sort(),
+		C.uint(i), C.uint(j)), sort.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return sort.ctx.newFuncDecl(z3decl)
+}
@@ -0,0 +1,85 @@
+package z3
+
+import "testing"
+
+func TestEnumSort(t *testing.T) {
+	ctx := getContext()
+	color := ctx.EnumSort("Color", "red", "green", "blue")
+	if kind := color.SortKind(); kind != DataTypeSort {
+		t.Fatal("Expected sort kind", DataTypeSort, "got", kind, ctx.LastError)
+	}
+	if n := color.NumConstructors(); n != 3 {
+		t.Error("Expected 3 constructors, got", n)
+	}
+	c := ctx.Constant("c", color)
+	solver := NewSolver(ctx)
+	for i := uint(0); i < 3; i++ {
+		solver.Add(Not(Eq(c, color.Constructor(i).Apply())))
+	}
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+	if decl := color.Constructor(3); decl != nil {
+		t.Error("Expected nil constructor, got", decl)
+	}
+}
+
+func TestTupleAndListSorts(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	pair := ctx.TupleSort("Pair", []string{"first", "second"}, []*Sort{intSort, ctx.BoolSort()})
+	list := ctx.ListSort("IntList", intSort)
+	p := pair.Constructor(0).Apply(ctx.IntVal(3), ctx.BoolVal(true))
+	l := list.Constructor(1).Apply(pair.Accessor(0, 0).Apply(p), list.Constructor(0).Apply())
+	x := ctx.IntConst("x")
+	checkModel(t, ctx, []*Expr{
+		Eq(x, list.Accessor(1, 0).Apply(l)),
+	}, []*Expr{
+		x,
+		list.Recognizer(0).Apply(list.Accessor(1, 1).Apply(l)),
+		pair.Accessor(0, 1).Apply(p),
+	}, []string{"3", "true", "true"})
+}
+
+func TestRecursiveDatatypes(t *testing.T) {
+	ctx := getContext()
+	sorts := ctx.Datatypes(
+		DatatypeDecl{"Tree", []Constructor{
+			{Name: "leaf", Fields: []Field{{Name: "value", Sort: ctx.IntSort()}}},
+			{Name: "node", Fields: []Field{{Name: "children", Recursive: true, SortRef: 1}}},
+		}},
+		DatatypeDecl{"Forest", []Constructor{
+			{Name: "empty"},
+			{Name: "insert", Fields: []Field{{Name: "head", Recursive: true}, {Name: "tail", Recursive: true, SortRef: 1}}},
+		}})
+	if sorts == nil {
+		t.Fatal("Expected valid sorts, got nil:", ctx.LastError)
+	}
+	tree, forest := sorts[0], sorts[1]
+	if name := tree.Accessor(1, 0).Range().Name(); name != "Forest" {
+		t.Error("Expected Forest, got", name)
+	}
+
+	// A tree cannot be its own child.
+	x := ctx.Constant("x", tree)
+	empty := forest.Constructor(0).Apply()
+	solver := NewSolver(ctx)
+	solver.Add(Eq(x, tree.Constructor(1).Apply(forest.Constructor(1).Apply(x, empty))))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+}
+
+func TestInvalidDatatypeFields(t *testing.T) {
+	ctx := getContext()
+	for _, field := range []Field{
+		{Name: "missing"},
+		{Name: "both", Sort: ctx.IntSort(), Recursive: true},
+		{Name: "dangling", Recursive: true, SortRef: 1},
+	} {
+		if sort := ctx.Datatype("Bad", Constructor{Name: "bad", Fields: []Field{field}}); sort != nil {
+			t.Error("Expected nil for field", field.Name, "got", sort)
+		}
+		expectErrorCode(t, ctx.LastError, InvalidArg)
+	}
+}
@@ -0,0 +1,91 @@
+package z3
+
+import "testing"
+
+func expectErrorCode(t *testing.T, err error, code ErrorCode) {
+	if e, ok := err.(*Error); !ok || e.Code != code {
+		t.Error("Expected", code, "error, got", err)
+	}
+}
+
+func TestSortMismatch(t *testing.T) {
+	ctx := getContext()
+	if expr := Eq(ctx.IntConst("x"), ctx.BoolConst("b")); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	// Depending on the Z3 version, this is a SortError or an Exception.
+	err, ok := ctx.Err().(*Error)
+	if !ok {
+		t.Fatal("Expected Z3 error, got", ctx.Err())
+	}
+
+	// The first error sticks until cleared.
+	if expr := Eq(ctx.IntConst("x"), ctx.IntVal(1)); expr == nil {
+		t.Error("Expected valid expression, got nil")
+	}
+	if ctx.Err() != err {
+		t.Error("Expected", err, "got", ctx.Err())
+	}
+	ctx.ClearErr()
+	if err := ctx.Err(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+}
+
+func TestNilOperands(t *testing.T) {
+	ctx := getContext()
+	x := ctx.BVConst("x", 32)
+	if expr := Eq(x, ctx.Constant("y", nil)); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	expectErrorCode(t, ctx.Err(), InvalidArg)
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+	if expr := Ite(nil, x, x); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	expectErrorCode(t, NewSolver(ctx).Add(nil), InvalidArg)
+
+	// Without a single operand, there is no context to record an error in.
+	ctx.ClearErr()
+	if expr := Ite(nil, nil, nil); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	if err := ctx.Err(); err != nil {
+		t.Error("Expected no error, got", err)
+	}
+}
+
+func TestEmptyOperands(t *testing.T) {
+	ctx := getContext()
+	tests := []struct {
+		expr     *Expr
+		expected string
+	}{
+		{ctx.And(), "true"},
+		{ctx.Or(), "false"},
+		{ctx.Distinct(), "true"},
+	}
+	for _, test := range tests {
+		if test.expr == nil {
+			t.Error("Expected", test.expected, "got nil:", ctx.LastError)
+		} else if s := test.expr.String(); s != test.expected {
+			t.Error("Expected", test.expected, "got", s)
+		}
+	}
+	if expr := And(); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+}
+
+func TestMixedContexts(t *testing.T) {
+	ctx1, ctx2 := getContext(), getContext()
+	if expr := Or(ctx1.BoolConst("a"), ctx2.BoolConst("b")); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	expectErrorCode(t, ctx1.Err(), InvalidArg)
+	if expr := ctx2.And(ctx1.BoolConst("a")); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	expectErrorCode(t, ctx2.Err(), InvalidArg)
+	expectErrorCode(t, NewSolver(ctx2).Add(ctx1.BoolConst("a")), InvalidArg)
+}
@@ -0,0 +1,362 @@
+package z3
+
+// #include <stdlib.h>
+// #include <z3.h>
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Fixedpoint engines
+
+// Fixedpoint encapsulates a Z3 fixedpoint engine, which solves Datalog
+// programs and constrained Horn clauses over registered relations.
+type Fixedpoint struct {
+	z3val  C.Z3_fixedpoint
+	ctx    *Context
+	closed bool
+	descrs paramDescrsCache
+}
+
+// NewFixedpoint creates a new Z3 fixedpoint engine. It returns nil if the
+// context is closed.
+func NewFixedpoint(ctx *Context) *Fixedpoint {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	fp := &Fixedpoint{z3val: C.Z3_mk_fixedpoint(ctx.z3val), ctx: ctx}
+	C.Z3_fixedpoint_inc_ref(ctx.z3val, fp.z3val)
+	ctx.acquire()
+	runtime.SetFinalizer(fp, (*Fixedpoint).finalize)
+	return fp
+}
+
+// String returns the rules and assertions of the engine in SMT-LIB format.
+func (fp *Fixedpoint) String() string {
+	return fp.SMTLIB()
+}
+
+// SMTLIB returns the rules and assertions of the engine, followed by the given
+// queries, in SMT-LIB format.
+func (fp *Fixedpoint) SMTLIB(queries ...*Expr) string {
+	if fp.closed {
+		return ""
+	}
+	if err := fp.ctx.checkOperands(queries...); err != nil {
+		return ""
+	}
+	asts := extractASTs(queries)
+	return C.GoString(C.Z3_fixedpoint_to_string(fp.ctx.z3val, fp.z3val, C.uint(len(asts)), astsPtr(asts)))
+}
+
+// Close releases the engine. Calling Close more than once is a no-op.
+func (fp *Fixedpoint) Close() error {
+	if !fp.closed {
+		fp.closed = true
+		runtime.SetFinalizer(fp, nil)
+		fp.descrs.close()
+		fp.ctx.releasePending()
+		fp.ctx.release(fp.decRef())
+	}
+	return nil
+}
+
+func (fp *Fixedpoint) finalize() {
+	fp.ctx.queueRelease(fp.decRef())
+}
+
+func (fp *Fixedpoint) decRef() func() {
+	z3ctx, z3val := fp.ctx.z3val, fp.z3val
+	return func() {
+		C.Z3_fixedpoint_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (fp *Fixedpoint) checkOpen() error {
+	if fp.closed {
+		return fp.ctx.usageError("fixedpoint engine is closed")
+	}
+	return nil
+}
+
+// SetParamString sets an engine parameter using a string value, such as
+// "engine" to "spacer" or "datalog".
+func (fp *Fixedpoint) SetParamString(name, value string) error {
+	return fp.setParam(func(params *Params) error { return params.SetSymbol(name, value) })
+}
+
+// SetParamUint sets an engine parameter using an unsigned value.
+func (fp *Fixedpoint) SetParamUint(name string, value uint) error {
+	return fp.setParam(func(params *Params) error { return params.SetUint(name, value) })
+}
+
+// SetParamBool sets an engine parameter using a bool value.
+func (fp *Fixedpoint) SetParamBool(name string, value bool) error {
+	return fp.setParam(func(params *Params) error { return params.SetBool(name, value) })
+}
+
+func (fp *Fixedpoint) setParam(set func(*Params) error) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	params := NewParams(fp.ctx)
+	if params == nil {
+		return fp.ctx.LastError
+	}
+	defer params.Close()
+	if err := set(params); err != nil {
+		return err
+	}
+	return fp.SetParams(params)
+}
+
+// ParamDescrs describes the parameters accepted by SetParams.
+func (fp *Fixedpoint) ParamDescrs() *ParamDescrs {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	return fp.descrs.paramDescrs(fp.ctx, fp.describeParams)
+}
+
+func (fp *Fixedpoint) describeParams() C.Z3_param_descrs {
+	return C.Z3_fixedpoint_get_param_descrs(fp.ctx.z3val, fp.z3val)
+}
+
+// SetParams configures the engine. Parameters are checked against ParamDescrs
+// by Params.Validate.
+func (fp *Fixedpoint) SetParams(params *Params) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	return fp.descrs.setParams(fp.ctx, params, fp.describeParams, func(z3params C.Z3_params) {
+		C.Z3_fixedpoint_set_params(fp.ctx.z3val, fp.z3val, z3params)
+	})
+}
+
+// RegisterRelation declares f as a relation of the engine, whose
+// interpretation is defined by rules and facts.
+func (fp *Fixedpoint) RegisterRelation(f *FuncDecl) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkFuncDecls(f); err != nil {
+		return err
+	}
+	C.Z3_fixedpoint_register_relation(fp.ctx.z3val, fp.z3val, f.z3funcdecl())
+	return fp.ctx.getError()
+}
+
+// AddRule adds a Horn clause, usually a universally quantified implication
+// whose head is an application of a registered relation. The name may be
+// empty.
+func (fp *Fixedpoint) AddRule(rule *Expr, name string) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkOperands(rule); err != nil {
+		return err
+	}
+	sym := fp.ctx.NewStringSymbol(name)
+	C.Z3_fixedpoint_add_rule(fp.ctx.z3val, fp.z3val, rule.z3val, sym.z3val)
+	return fp.ctx.getError()
+}
+
+// AddFact adds a fact for the relation r, whose arguments must all be of
+// finite domain or bit-vector sort. Each argument is given as the index of
+// the element in its sort.
+func (fp *Fixedpoint) AddFact(r *FuncDecl, args ...uint) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkFuncDecls(r); err != nil {
+		return err
+	}
+	cArgs := make([]C.uint, len(args))
+	for i, arg := range args {
+		cArgs[i] = C.uint(arg)
+	}
+	var argsPtr *C.uint
+	if len(cArgs) > 0 {
+		argsPtr = &cArgs[0]
+	}
+	C.Z3_fixedpoint_add_fact(fp.ctx.z3val, fp.z3val, r.z3funcdecl(), C.uint(len(cArgs)), argsPtr)
+	return fp.ctx.getError()
+}
+
+// Assert adds a background axiom, which may not mention registered
+// relations.
+func (fp *Fixedpoint) Assert(a *Expr) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkOperands(a); err != nil {
+		return err
+	}
+	C.Z3_fixedpoint_assert(fp.ctx.z3val, fp.z3val, a.z3val)
+	return fp.ctx.getError()
+}
+
+// Query checks whether the formula q, which may contain existentially
+// quantified variables, is derivable from the rules. LTrue means it is
+// derivable, in which case Answer returns the derivation or the reachable
+// instances depending on the engine, and Reachable the states of a relation
+// found reachable by Spacer; LFalse means it is not, in which case Answer
+// returns an inductive invariant.
+func (fp *Fixedpoint) Query(q *Expr) (result LiftedBool, err error) {
+	if err = fp.checkOpen(); err != nil {
+		return LUndef, err
+	}
+	if err = fp.ctx.checkOperands(q); err != nil {
+		return LUndef, err
+	}
+	result = LiftedBool(C.Z3_fixedpoint_query(fp.ctx.z3val, fp.z3val, q.z3val))
+	err = unknownError(result, fp.ctx.getError(), fp.ReasonUnknown)
+	return
+}
+
+// QueryRelations checks whether any tuple of the given relations is derivable
+// from the rules and facts.
+func (fp *Fixedpoint) QueryRelations(r ...*FuncDecl) (result LiftedBool, err error) {
+	if err = fp.checkOpen(); err != nil {
+		return LUndef, err
+	}
+	if err = fp.ctx.checkFuncDecls(r...); err != nil {
+		return LUndef, err
+	}
+	decls := make([]C.Z3_func_decl, len(r))
+	for i, f := range r {
+		decls[i] = f.z3funcdecl()
+	}
+	var declsPtr *C.Z3_func_decl
+	if len(decls) > 0 {
+		declsPtr = &decls[0]
+	}
+	result = LiftedBool(C.Z3_fixedpoint_query_relations(fp.ctx.z3val, fp.z3val, C.uint(len(decls)), declsPtr))
+	err = unknownError(result, fp.ctx.getError(), fp.ReasonUnknown)
+	return
+}
+
+// ReasonUnknown returns a brief description of why the last query returned
+// LUndef.
+func (fp *Fixedpoint) ReasonUnknown() string {
+	if fp.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_fixedpoint_get_reason_unknown(fp.ctx.z3val, fp.z3val))
+}
+
+// Answer returns the answer to the last query, as described by Query.
+func (fp *Fixedpoint) Answer() *Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_fixedpoint_get_answer(fp.ctx.z3val, fp.z3val), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExpr(z3ast)
+}
+
+// NumLevels returns the number of unfolding levels explored for the relation
+// pred by the last query.
+func (fp *Fixedpoint) NumLevels(pred *FuncDecl) (uint, error) {
+	if err := fp.checkOpen(); err != nil {
+		return 0, err
+	}
+	if err := fp.ctx.checkFuncDecls(pred); err != nil {
+		return 0, err
+	}
+	n, err := C.Z3_fixedpoint_get_num_levels(fp.ctx.z3val, fp.z3val, pred.z3funcdecl()), fp.ctx.getError()
+	return uint(n), err
+}
+
+// CoverDelta returns the property over the arguments of pred that was learned
+// at the given level, or at every level if level is -1.
+func (fp *Fixedpoint) CoverDelta(level int, pred *FuncDecl) *Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	if err := fp.ctx.checkFuncDecls(pred); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_fixedpoint_get_cover_delta(fp.ctx.z3val, fp.z3val, C.int(level), pred.z3funcdecl()), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExpr(z3ast)
+}
+
+// Reachable returns the states of the relation pred found reachable by the
+// last query, over the bound variables 0 to n-1 standing for the n arguments
+// of pred. It is specific to the Spacer engine.
+func (fp *Fixedpoint) Reachable(pred *FuncDecl) *Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	if err := fp.ctx.checkFuncDecls(pred); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_fixedpoint_get_reachable(fp.ctx.z3val, fp.z3val, pred.z3funcdecl()), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExpr(z3ast)
+}
+
+// AddCover adds a property of the relation pred at the given level, or at
+// every level if level is -1. The property ranges over the bound variables
+// 0 to n-1 standing for the n arguments of pred.
+func (fp *Fixedpoint) AddCover(level int, pred *FuncDecl, property *Expr) error {
+	if err := fp.checkOpen(); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkFuncDecls(pred); err != nil {
+		return err
+	}
+	if err := fp.ctx.checkOperands(property); err != nil {
+		return err
+	}
+	C.Z3_fixedpoint_add_cover(fp.ctx.z3val, fp.z3val, C.int(level), pred.z3funcdecl(), property.z3val)
+	return fp.ctx.getError()
+}
+
+// Rules returns the rules added to the engine.
+func (fp *Fixedpoint) Rules() []*Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	v, err := C.Z3_fixedpoint_get_rules(fp.ctx.z3val, fp.z3val), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExprs(v)
+}
+
+// Assertions returns the background axioms added to the engine.
+func (fp *Fixedpoint) Assertions() []*Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	v, err := C.Z3_fixedpoint_get_assertions(fp.ctx.z3val, fp.z3val), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExprs(v)
+}
+
+// ParseString parses rules and queries in SMT-LIB format, adding the rules to
+// the engine and returning the queries.
+func (fp *Fixedpoint) ParseString(s string) []*Expr {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	v, err := C.Z3_fixedpoint_from_string(fp.ctx.z3val, fp.z3val, cs), fp.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return fp.ctx.newExprs(v)
+}
@@ -0,0 +1,98 @@
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFixedpointHorn(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	inv := ctx.FuncDecl("inv", []*Sort{intSort}, ctx.BoolSort())
+	x := ctx.IntConst("x")
+
+	fp := NewFixedpoint(ctx)
+	defer fp.Close()
+	if err := fp.SetParamString("engine", "spacer"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	// Spacer only reports reachable states with slicing disabled.
+	if err := fp.SetParamBool("xform.slice", false); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	fp.RegisterRelation(inv)
+	fp.AddRule(ForAll([]*Expr{x}, Implies(Eq(x, ctx.IntVal(0)), inv.Apply(x))), "init")
+	fp.AddRule(ForAll([]*Expr{x}, Implies(And(inv.Apply(x), Lt(x, ctx.IntVal(10))),
+		inv.Apply(Add(x, ctx.IntVal(1))))), "step")
+	if rules := fp.Rules(); len(rules) != 2 {
+		t.Error("Expected 2 rules, got", len(rules))
+	}
+	if s := fp.String(); !strings.Contains(s, "inv") {
+		t.Error("Expected rules to mention inv, got", s)
+	}
+
+	bad := Exists([]*Expr{x}, And(inv.Apply(x), Gt(x, ctx.IntVal(10))))
+	if result, err := fp.Query(bad); result != LFalse || err != nil {
+		t.Fatal("Expected", LFalse, "got", result, err)
+	}
+	if answer := fp.Answer(); answer == nil {
+		t.Error("Expected an invariant, got", ctx.LastError)
+	}
+	if cover := fp.CoverDelta(-1, inv); cover == nil {
+		t.Error("Unexpected error:", ctx.LastError)
+	}
+
+	good := Exists([]*Expr{x}, And(inv.Apply(x), Eq(x, ctx.IntVal(5))))
+	if result, err := fp.Query(good); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+	if reachable := fp.Reachable(inv); reachable == nil || !strings.Contains(reachable.String(), "(= (:var 0) 5)") {
+		t.Error("Expected reachable states up to 5, got", reachable, ctx.LastError)
+	}
+}
+
+func TestFixedpointDatalog(t *testing.T) {
+	ctx := getContext()
+	node := ctx.BVSort(3)
+	edge := ctx.FuncDecl("edge", []*Sort{node, node}, ctx.BoolSort())
+	path := ctx.FuncDecl("path", []*Sort{node, node}, ctx.BoolSort())
+	a, b, c := ctx.BVConst("a", 3), ctx.BVConst("b", 3), ctx.BVConst("c", 3)
+
+	fp := NewFixedpoint(ctx)
+	defer fp.Close()
+	fp.SetParamString("engine", "datalog")
+	fp.RegisterRelation(edge)
+	fp.RegisterRelation(path)
+	fp.AddRule(ForAll([]*Expr{a, b}, Implies(edge.Apply(a, b), path.Apply(a, b))), "")
+	fp.AddRule(ForAll([]*Expr{a, b, c}, Implies(And(path.Apply(a, b), edge.Apply(b, c)),
+		path.Apply(a, c))), "")
+	fp.AddFact(edge, 1, 2)
+	fp.AddFact(edge, 2, 3)
+
+	if result, err := fp.Query(path.Apply(ctx.BVUintVal(1, 3), ctx.BVUintVal(3, 3))); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+	if result, err := fp.Query(path.Apply(ctx.BVUintVal(3, 3), ctx.BVUintVal(1, 3))); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+	if result, err := fp.QueryRelations(path); result != LTrue || err != nil {
+		t.Error("Expected", LTrue, "got", result, err)
+	}
+}
+
+func TestFixedpointParams(t *testing.T) {
+	ctx := getContext()
+	fp := NewFixedpoint(ctx)
+	if err := fp.SetParamBool("no.such.param", true); err == nil {
+		t.Error("Expected error for unknown parameter")
+	}
+	fp.Close()
+	expectUsageError(t, fp.Assert(ctx.BoolVal(true)))
+}
+
+func TestFixedpointFinalizer(t *testing.T) {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	NewFixedpoint(ctx).Assert(ctx.BoolConst("p"))
+	waitForDeletion(t, deleted)
+}
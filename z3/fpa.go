@@ -0,0 +1,769 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import (
+	"fmt"
+	"math"
+)
+
+// -----------------------------------------------------------------------------
+// Floating-point sorts
+
+// FPSort returns the IEEE 754 floating-point sort with the given number of
+// exponent and significand bits, the latter including the hidden bit.
+func (ctx *Context) FPSort(ebits, sbits uint) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_sort(ctx.z3val, C.uint(ebits), C.uint(sbits)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// Float16Sort returns the IEEE 754 half-precision floating-point sort.
+func (ctx *Context) Float16Sort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_sort_16(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// Float32Sort returns the IEEE 754 single-precision floating-point sort.
+func (ctx *Context) Float32Sort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_sort_32(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// Float64Sort returns the IEEE 754 double-precision floating-point sort.
+func (ctx *Context) Float64Sort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_sort_64(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// Float128Sort returns the IEEE 754 quadruple-precision floating-point sort.
+func (ctx *Context) Float128Sort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_sort_128(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// RoundingModeSort returns the sort of floating-point rounding modes.
+func (ctx *Context) RoundingModeSort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_fpa_rounding_mode_sort(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// FPEBits returns the number of exponent bits of a floating-point sort.
+func (sort *Sort) FPEBits() uint {
+	ebits, err := C.Z3_fpa_get_ebits(sort.ctx.z3val, sort.z3sort()), sort.ctx.getError()
+	if err != nil {
+		return 0
+	}
+	return uint(ebits)
+}
+
+// FPSBits returns the number of significand bits of a floating-point sort,
+// including the hidden bit.
+func (sort *Sort) FPSBits() uint {
+	sbits, err := C.Z3_fpa_get_sbits(sort.ctx.z3val, sort.z3sort()), sort.ctx.getError()
+	if err != nil {
+		return 0
+	}
+	return uint(sbits)
+}
+
+// -----------------------------------------------------------------------------
+// Rounding modes
+
+// RoundNearestTiesToEven returns the rounding mode that rounds to the nearest
+// value, breaking ties towards the even one.
+func (ctx *Context) RoundNearestTiesToEven() *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rne(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// RoundNearestTiesToAway returns the rounding mode that rounds to the nearest
+// value, breaking ties away from zero.
+func (ctx *Context) RoundNearestTiesToAway() *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rna(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// RoundTowardPositive returns the rounding mode that rounds towards +oo.
+func (ctx *Context) RoundTowardPositive() *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rtp(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// RoundTowardNegative returns the rounding mode that rounds towards -oo.
+func (ctx *Context) RoundTowardNegative() *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rtn(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// RoundTowardZero returns the rounding mode that rounds towards zero.
+func (ctx *Context) RoundTowardZero() *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rtz(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// -----------------------------------------------------------------------------
+// Floating-point numerals
+
+// FPNaN returns the NaN value of a floating-point sort.
+func (ctx *Context) FPNaN(sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_nan(ctx.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPInf returns the positive or negative infinity of a floating-point sort.
+func (ctx *Context) FPInf(sort *Sort, negative bool) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_inf(ctx.z3val, sort.z3sort(), getZ3Bool(negative)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPZero returns the positive or negative zero of a floating-point sort.
+func (ctx *Context) FPZero(sort *Sort, negative bool) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_zero(ctx.z3val, sort.z3sort(), getZ3Bool(negative)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPVal returns the value of f in the given floating-point sort, rounded to
+// the nearest representable value if needed. NaN, infinities and signed zeros
+// are preserved.
+func (ctx *Context) FPVal(f float64, sort *Sort) *Expr {
+	return ctx.fpVal(f, sort, func() *Expr {
+		if sort.FPEBits() != 11 || sort.FPSBits() != 53 {
+			// Z3 builds wrong numerals of other sorts from subnormal doubles,
+			// so the value is rounded from double precision instead.
+			return roundFP(ctx.Float64Val(f), sort)
+		}
+		z3ast, err := C.Z3_mk_fpa_numeral_double(ctx.z3val, C.double(f), sort.z3sort()), ctx.getError()
+		if err != nil {
+			return nil
+		}
+		return ctx.newExpr(z3ast)
+	})
+}
+
+// Float32Val returns the single-precision numeral with the exact value of f.
+func (ctx *Context) Float32Val(f float32) *Expr {
+	sort := ctx.Float32Sort()
+	return ctx.fpVal(float64(f), sort, func() *Expr {
+		z3ast, err := C.Z3_mk_fpa_numeral_float(ctx.z3val, C.float(f), sort.z3sort()), ctx.getError()
+		if err != nil {
+			return nil
+		}
+		return ctx.newExpr(z3ast)
+	})
+}
+
+// Float64Val returns the double-precision numeral with the exact value of f.
+func (ctx *Context) Float64Val(f float64) *Expr {
+	return ctx.FPVal(f, ctx.Float64Sort())
+}
+
+// fpVal returns the numeral f of the given sort, using the special value
+// constructors for NaN, infinities and zeros, and numeral for the others.
+func (ctx *Context) fpVal(f float64, sort *Sort, numeral func() *Expr) *Expr {
+	switch {
+	case math.IsNaN(f):
+		return ctx.FPNaN(sort)
+	case math.IsInf(f, 0):
+		return ctx.FPInf(sort, f < 0)
+	case f == 0:
+		return ctx.FPZero(sort, math.Signbit(f))
+	}
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	return numeral()
+}
+
+// roundFP returns the numeral of the given floating-point sort nearest to the
+// floating-point numeral value, obtained by simplifying the conversion.
+func roundFP(value *Expr, sort *Sort) *Expr {
+	if value == nil {
+		return nil
+	}
+	ctx := value.ctx
+	rounded := FPToFP(ctx.RoundNearestTiesToEven(), value, sort)
+	if rounded == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_simplify(ctx.z3val, rounded.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	if numeral := ctx.newExpr(z3ast); numeral.IsNumeral() {
+		return numeral
+	}
+	ctx.setError(&Error{InvalidArg, fmt.Sprintf("cannot round %s to a numeral of sort %s", value, sort)})
+	return nil
+}
+
+// FP returns the floating-point value with the given sign bit, biased exponent
+// and significand without the hidden bit, as bit-vectors.
+func FP(sign, exponent, significand *Expr) *Expr {
+	ctx := operandContext(sign, exponent, significand)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_fp(ctx.z3val, sign.z3val, exponent.z3val, significand.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// -----------------------------------------------------------------------------
+// Floating-point operations
+
+// FPAbs returns the absolute value of a.
+func FPAbs(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_abs(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPNeg returns the negation of a.
+func FPNeg(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_neg(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPAdd returns a + b, rounded with rm.
+func FPAdd(rm, a, b *Expr) *Expr {
+	ctx := operandContext(rm, a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_add(ctx.z3val, rm.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPSub returns a - b, rounded with rm.
+func FPSub(rm, a, b *Expr) *Expr {
+	ctx := operandContext(rm, a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_sub(ctx.z3val, rm.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPMul returns a * b, rounded with rm.
+func FPMul(rm, a, b *Expr) *Expr {
+	ctx := operandContext(rm, a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_mul(ctx.z3val, rm.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPDiv returns a / b, rounded with rm.
+func FPDiv(rm, a, b *Expr) *Expr {
+	ctx := operandContext(rm, a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_div(ctx.z3val, rm.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPFMA returns a * b + c, rounded once with rm.
+func FPFMA(rm, a, b, c *Expr) *Expr {
+	ctx := operandContext(rm, a, b, c)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_fma(ctx.z3val, rm.z3val, a.z3val, b.z3val, c.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPSqrt returns the square root of a, rounded with rm.
+func FPSqrt(rm, a *Expr) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_sqrt(ctx.z3val, rm.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPRem returns the IEEE 754 remainder of a divided by b.
+func FPRem(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_rem(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPRoundToIntegral rounds a to an integral value with rm.
+func FPRoundToIntegral(rm, a *Expr) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_round_to_integral(ctx.z3val, rm.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPMin returns the minimum of a and b.
+func FPMin(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_min(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPMax returns the maximum of a and b.
+func FPMax(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_max(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Comparisons
+
+// FPLe checks whether a <= b.
+func FPLe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_leq(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPLt checks whether a < b.
+func FPLt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_lt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPGe checks whether a >= b.
+func FPGe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_geq(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPGt checks whether a > b.
+func FPGt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_gt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPEq checks whether a and b are equal as IEEE 754 numbers, unlike Eq: NaN is not
+// equal to itself, while the two zeros are equal.
+func FPEq(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_eq(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Classification
+
+// FPIsNormal checks whether a is a normal number.
+func FPIsNormal(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_normal(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsSubnormal checks whether a is a subnormal number.
+func FPIsSubnormal(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_subnormal(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsZero checks whether a is a zero of either sign.
+func FPIsZero(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_zero(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsInfinite checks whether a is an infinity of either sign.
+func FPIsInfinite(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_infinite(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsNaN checks whether a is NaN.
+func FPIsNaN(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_nan(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsNegative checks whether a is negative and not NaN.
+func FPIsNegative(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_negative(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPIsPositive checks whether a is positive and not NaN.
+func FPIsPositive(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_is_positive(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Conversions
+
+// FPFromBV reinterprets the bit-vector a, in IEEE 754 interchange format, as a
+// value of the given floating-point sort.
+func FPFromBV(a *Expr, sort *Sort) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_fp_bv(ctx.z3val, a.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPToFP converts the floating-point value a to another floating-point sort,
+// rounding with rm.
+func FPToFP(rm, a *Expr, sort *Sort) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_fp_float(ctx.z3val, rm.z3val, a.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPFromReal converts the real a to a floating-point sort, rounding with rm.
+func FPFromReal(rm, a *Expr, sort *Sort) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_fp_real(ctx.z3val, rm.z3val, a.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPFromSigned converts the bit-vector a, as a signed integer, to a
+// floating-point sort, rounding with rm.
+func FPFromSigned(rm, a *Expr, sort *Sort) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_fp_signed(ctx.z3val, rm.z3val, a.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPFromUnsigned converts the bit-vector a, as an unsigned integer, to a
+// floating-point sort, rounding with rm.
+func FPFromUnsigned(rm, a *Expr, sort *Sort) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_fp_unsigned(ctx.z3val, rm.z3val, a.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPToUBV converts a to an unsigned bit-vector of the given size, rounding
+// with rm.
+func FPToUBV(rm, a *Expr, size uint) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_ubv(ctx.z3val, rm.z3val, a.z3val, C.uint(size)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPToSBV converts a to a signed bit-vector of the given size, rounding with
+// rm.
+func FPToSBV(rm, a *Expr, size uint) *Expr {
+	ctx := operandContext(rm, a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_sbv(ctx.z3val, rm.z3val, a.z3val, C.uint(size)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPToReal converts a to a real. The result is unspecified for NaN and
+// infinities.
+func FPToReal(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_real(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FPToIEEEBV converts a to a bit-vector in IEEE 754 interchange format. NaN
+// has several encodings, so the result is unspecified for it.
+func FPToIEEEBV(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_fpa_to_ieee_bv(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
@@ -0,0 +1,91 @@
+package z3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFPSorts(t *testing.T) {
+	ctx := getContext()
+	tests := []struct {
+		sort         *Sort
+		ebits, sbits uint
+	}{
+		{ctx.Float16Sort(), 5, 11},
+		{ctx.Float32Sort(), 8, 24},
+		{ctx.Float64Sort(), 11, 53},
+		{ctx.Float128Sort(), 15, 113},
+		{ctx.FPSort(3, 5), 3, 5},
+	}
+	for _, test := range tests {
+		if kind := test.sort.SortKind(); kind != FloatingPointSort {
+			t.Error("Expected sort kind", FloatingPointSort, "got", kind)
+		}
+		if ebits, sbits := test.sort.FPEBits(), test.sort.FPSBits(); ebits != test.ebits || sbits != test.sbits {
+			t.Error("Expected", test.ebits, test.sbits, "got", ebits, sbits)
+		}
+	}
+	if kind := ctx.RoundingModeSort().SortKind(); kind != RoundingModeSort {
+		t.Error("Expected sort kind", RoundingModeSort, "got", kind)
+	}
+}
+
+func TestFPNumerals(t *testing.T) {
+	ctx := getContext()
+	rne := ctx.RoundNearestTiesToEven()
+	a, b := 0.1, 0.2
+	checkModel(t, ctx, nil, []*Expr{
+		FPToIEEEBV(ctx.Float64Val(1.5)),
+		FPToIEEEBV(ctx.Float32Val(float32(math.Inf(-1)))),
+		FPIsNaN(ctx.Float32Val(float32(math.NaN()))),
+		FPIsNegative(ctx.Float64Val(math.Copysign(0, -1))),
+		FPEq(ctx.Float64Val(0), ctx.FPVal(math.Copysign(0, -1), ctx.Float64Sort())),
+		Eq(FPAdd(rne, ctx.Float64Val(a), ctx.Float64Val(b)), ctx.Float64Val(a+b)),
+		FPToSBV(ctx.RoundTowardZero(), ctx.FPVal(-2.5, ctx.Float16Sort()), 8),
+		FPIsSubnormal(FPToFP(rne, ctx.Float64Val(1e-40), ctx.Float32Sort())),
+	}, []string{"#x3ff8000000000000", "#xff800000", "true", "true", "true", "true", "#xfe", "true"})
+}
+
+func TestFPNumeralRoundTrip(t *testing.T) {
+	ctx := getContext()
+	for _, f := range []float64{1.5, -0.1, math.Copysign(0, -1), 5e-324, math.MaxFloat64, math.Inf(1), math.NaN()} {
+		value := ctx.Float64Val(f)
+		if !value.IsNumeral() {
+			t.Error("Expected numeral for", f, "got", value)
+		}
+		if g, err := value.Float64(); err != nil || math.Float64bits(g) != math.Float64bits(f) && !(math.IsNaN(f) && math.IsNaN(g)) {
+			t.Error("Expected", f, "got", g, err)
+		}
+	}
+	checkModel(t, ctx, nil, []*Expr{
+		FPToIEEEBV(ctx.Float32Val(-0.1)),
+		FPToIEEEBV(ctx.Float32Val(math.SmallestNonzeroFloat32)),
+		FPToIEEEBV(ctx.FPVal(math.SmallestNonzeroFloat32, ctx.Float32Sort())),
+		FPEq(ctx.FPVal(-1e-310, ctx.Float128Sort()), FPToFP(ctx.RoundNearestTiesToEven(), ctx.Float64Val(-1e-310), ctx.Float128Sort())),
+	}, []string{"#xbdcccccd", "#x00000001", "#x00000001", "true"})
+	for _, value := range []*Expr{ctx.Float32Val(1.5), ctx.FPVal(0.1, ctx.Float16Sort()), ctx.FPVal(-1e-310, ctx.Float128Sort())} {
+		if !value.IsNumeral() {
+			t.Error("Expected numeral, got", value)
+		}
+	}
+	if value := ctx.FPVal(1.5, ctx.IntSort()); value != nil {
+		t.Error("Expected nil for integer sort, got", value)
+	}
+}
+
+func TestFPOperations(t *testing.T) {
+	ctx := getContext()
+	sort := ctx.Float16Sort()
+	rm := ctx.RoundTowardPositive()
+	x := ctx.Constant("x", sort)
+	solver := NewSolver(ctx)
+	solver.Add(
+		Not(FPIsNaN(x)),
+		FPLt(FPSqrt(rm, FPMul(rm, x, x)), FPAbs(x)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+	if expr := FPAdd(rm, x, ctx.Float64Val(1)); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+}
@@ -0,0 +1,44 @@
+package z3
+
+import "testing"
+
+func TestFuncDecl(t *testing.T) {
+	ctx := getContext()
+	u := ctx.UninterpretedSort("U")
+	if kind := u.SortKind(); kind != UninterpretedSort {
+		t.Error("Expected sort kind", UninterpretedSort, "got", kind)
+	}
+	if name := u.Name(); name != "U" {
+		t.Error("Expected sort name U, got", name)
+	}
+
+	f := ctx.FuncDecl("f", []*Sort{u, u}, ctx.BoolSort())
+	if f == nil {
+		t.Fatal("Expected valid function declaration, got nil:", ctx.LastError)
+	}
+	if name, arity := f.Name(), f.Arity(); name != "f" || arity != 2 {
+		t.Error("Expected f/2, got", name, arity)
+	}
+	if domain := f.Domain(); len(domain) != 2 || domain[1].Name() != "U" {
+		t.Error("Expected domain [U U], got", domain)
+	}
+	if rng := f.Range(); rng.SortKind() != BoolSort {
+		t.Error("Expected range Bool, got", rng)
+	}
+
+	// Congruence: a = b implies f(a, c) = f(b, c).
+	a, b, c := ctx.Constant("a", u), ctx.Constant("b", u), ctx.FreshConstant("c", u)
+	solver := NewSolver(ctx)
+	solver.Add(Eq(a, b), f.Apply(a, c), Not(f.Apply(b, c)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+
+	if expr := f.Apply(a); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+	g := ctx.FreshFuncDecl("g", nil, u)
+	if expr := g.Apply(); expr == nil || g.Arity() != 0 {
+		t.Error("Expected constant application, got", expr, ctx.LastError)
+	}
+}
@@ -0,0 +1,292 @@
+package z3
+
+// #include <stdlib.h>
+// #include <z3.h>
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Optimizers
+
+// Priority determines how an Optimizer combines multiple objectives.
+type Priority int
+
+const (
+	// PriorityLex optimizes the objectives lexicographically, in the order in
+	// which they were added. This is the default.
+	PriorityLex Priority = iota
+	// PriorityPareto enumerates Pareto-optimal solutions, one per Check.
+	PriorityPareto
+	// PriorityBox optimizes each objective independently.
+	PriorityBox
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLex:
+		return "lex"
+	case PriorityPareto:
+		return "pareto"
+	case PriorityBox:
+		return "box"
+	default:
+		return ""
+	}
+}
+
+// Optimizer encapsulates a Z3 optimization context, which solves hard
+// constraints while optimizing soft constraints and objectives.
+type Optimizer struct {
+	z3val  C.Z3_optimize
+	ctx    *Context
+	closed bool
+	descrs paramDescrsCache
+}
+
+// Objective is a handle to an objective of an Optimizer, either a minimized or
+// maximized term or a group of soft constraints.
+type Objective struct {
+	opt   *Optimizer
+	index uint
+}
+
+// NewOptimizer creates a new Z3 optimizer. It returns nil if the context is
+// closed.
+func NewOptimizer(ctx *Context) *Optimizer {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	opt := &Optimizer{z3val: C.Z3_mk_optimize(ctx.z3val), ctx: ctx}
+	C.Z3_optimize_inc_ref(ctx.z3val, opt.z3val)
+	ctx.acquire()
+	runtime.SetFinalizer(opt, (*Optimizer).finalize)
+	return opt
+}
+
+func (opt *Optimizer) String() string {
+	if opt.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_optimize_to_string(opt.ctx.z3val, opt.z3val))
+}
+
+// Close releases the optimizer. Calling Close more than once is a no-op.
+func (opt *Optimizer) Close() error {
+	if !opt.closed {
+		opt.closed = true
+		runtime.SetFinalizer(opt, nil)
+		opt.descrs.close()
+		opt.ctx.releasePending()
+		opt.ctx.release(opt.decRef())
+	}
+	return nil
+}
+
+func (opt *Optimizer) finalize() {
+	opt.ctx.queueRelease(opt.decRef())
+}
+
+func (opt *Optimizer) decRef() func() {
+	z3ctx, z3val := opt.ctx.z3val, opt.z3val
+	return func() {
+		C.Z3_optimize_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (opt *Optimizer) checkOpen() error {
+	if opt.closed {
+		return opt.ctx.usageError("optimizer is closed")
+	}
+	return nil
+}
+
+// SetPriority sets how the optimizer combines multiple objectives.
+func (opt *Optimizer) SetPriority(p Priority) error {
+	if err := opt.checkOpen(); err != nil {
+		return err
+	}
+	params := NewParams(opt.ctx)
+	defer params.Close()
+	params.SetSymbol("priority", p.String())
+	return opt.SetParams(params)
+}
+
+// ParamDescrs describes the parameters accepted by SetParams.
+func (opt *Optimizer) ParamDescrs() *ParamDescrs {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	return opt.descrs.paramDescrs(opt.ctx, opt.describeParams)
+}
+
+func (opt *Optimizer) describeParams() C.Z3_param_descrs {
+	return C.Z3_optimize_get_param_descrs(opt.ctx.z3val, opt.z3val)
+}
+
+// SetParams configures the optimizer. Parameters are checked against
+// ParamDescrs by Params.Validate.
+func (opt *Optimizer) SetParams(params *Params) error {
+	if err := opt.checkOpen(); err != nil {
+		return err
+	}
+	return opt.descrs.setParams(opt.ctx, params, opt.describeParams, func(z3params C.Z3_params) {
+		C.Z3_optimize_set_params(opt.ctx.z3val, opt.z3val, z3params)
+	})
+}
+
+func (opt *Optimizer) Push() error {
+	if err := opt.checkOpen(); err != nil {
+		return err
+	}
+	C.Z3_optimize_push(opt.ctx.z3val, opt.z3val)
+	return opt.ctx.getError()
+}
+
+// Pop removes the n most recent backtracking points.
+func (opt *Optimizer) Pop(n uint) error {
+	if err := opt.checkOpen(); err != nil {
+		return err
+	}
+	for i := uint(0); i < n; i++ {
+		C.Z3_optimize_pop(opt.ctx.z3val, opt.z3val)
+		if err := opt.ctx.getError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add asserts hard constraints.
+func (opt *Optimizer) Add(a ...*Expr) error {
+	if err := opt.checkOpen(); err != nil {
+		return err
+	}
+	for _, expr := range a {
+		if err := opt.ctx.checkOperands(expr); err != nil {
+			return err
+		}
+		C.Z3_optimize_assert(opt.ctx.z3val, opt.z3val, expr.z3val)
+		if err := opt.ctx.getError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSoft asserts a soft constraint, which the optimizer pays the penalty
+// weight for violating. The weight is a positive decimal numeral such as "1"
+// or "2.5". Soft constraints sharing a group are minimized together, and the
+// returned objective tracks the total penalty of that group.
+func (opt *Optimizer) AddSoft(a *Expr, weight string, group string) *Objective {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	if err := opt.ctx.checkOperands(a); err != nil {
+		return nil
+	}
+	cWeight := C.CString(weight)
+	defer C.free(unsafe.Pointer(cWeight))
+	sym := opt.ctx.NewStringSymbol(group)
+	index, err := C.Z3_optimize_assert_soft(opt.ctx.z3val, opt.z3val, a.z3val, cWeight, sym.z3val), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return &Objective{opt, uint(index)}
+}
+
+// Minimize adds the objective of minimizing the integer, real or bit-vector
+// term t.
+func (opt *Optimizer) Minimize(t *Expr) *Objective {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	if err := opt.ctx.checkOperands(t); err != nil {
+		return nil
+	}
+	index, err := C.Z3_optimize_minimize(opt.ctx.z3val, opt.z3val, t.z3val), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return &Objective{opt, uint(index)}
+}
+
+// Maximize adds the objective of maximizing the integer, real or bit-vector
+// term t.
+func (opt *Optimizer) Maximize(t *Expr) *Objective {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	if err := opt.ctx.checkOperands(t); err != nil {
+		return nil
+	}
+	index, err := C.Z3_optimize_maximize(opt.ctx.z3val, opt.z3val, t.z3val), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return &Objective{opt, uint(index)}
+}
+
+// Check checks the hard constraints together with the given assumptions and
+// optimizes the objectives.
+func (opt *Optimizer) Check(assumptions ...*Expr) (result LiftedBool, err error) {
+	if err = opt.checkOpen(); err != nil {
+		return LUndef, err
+	}
+	if err = opt.ctx.checkOperands(assumptions...); err != nil {
+		return LUndef, err
+	}
+	asts := extractASTs(assumptions)
+	result = LiftedBool(C.Z3_optimize_check(opt.ctx.z3val, opt.z3val, C.uint(len(asts)), astsPtr(asts)))
+	err = unknownError(result, opt.ctx.getError(), opt.ReasonUnknown)
+	return
+}
+
+// ReasonUnknown returns a brief description of why the last check returned
+// LUndef.
+func (opt *Optimizer) ReasonUnknown() string {
+	if opt.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_optimize_get_reason_unknown(opt.ctx.z3val, opt.z3val))
+}
+
+// GetModel returns the model found by the last Check.
+func (opt *Optimizer) GetModel() *Model {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	z3model, err := C.Z3_optimize_get_model(opt.ctx.z3val, opt.z3val), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return opt.ctx.newModel(z3model)
+}
+
+// Lower returns the lower bound of the objective found by the last Check.
+func (obj *Objective) Lower() *Expr {
+	opt := obj.opt
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_optimize_get_lower(opt.ctx.z3val, opt.z3val, C.uint(obj.index)), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return opt.ctx.newExpr(z3ast)
+}
+
+// Upper returns the upper bound of the objective found by the last Check.
+func (obj *Objective) Upper() *Expr {
+	opt := obj.opt
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_optimize_get_upper(opt.ctx.z3val, opt.z3val, C.uint(obj.index)), opt.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return opt.ctx.newExpr(z3ast)
+}
@@ -0,0 +1,89 @@
+package z3
+
+import "testing"
+
+func TestOptimizeObjectives(t *testing.T) {
+	ctx := getContext()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	opt := NewOptimizer(ctx)
+	defer opt.Close()
+	opt.Add(Le(ctx.IntVal(0), x), Le(x, ctx.IntVal(10)))
+	opt.Add(Le(ctx.IntVal(0), y), Le(Add(x, y), ctx.IntVal(15)))
+	maxX := opt.Maximize(x)
+	maxY := opt.Maximize(y)
+
+	if result, err := opt.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	// Lexicographic priority optimizes x first, then y.
+	if lower, upper := maxX.Lower().String(), maxX.Upper().String(); lower != "10" || upper != "10" {
+		t.Error("Expected bounds 10 for x, got", lower, upper)
+	}
+	if value := opt.GetModel().Eval(y, true).String(); value != "5" {
+		t.Error("Expected y = 5, got", value)
+	}
+
+	opt.SetPriority(PriorityBox)
+	if result, err := opt.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	if value := maxY.Upper().String(); value != "15" {
+		t.Error("Expected y bound 15 in box mode, got", value)
+	}
+}
+
+func TestOptimizeSoft(t *testing.T) {
+	ctx := getContext()
+	a, b, c := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")
+	opt := NewOptimizer(ctx)
+	defer opt.Close()
+	opt.Add(AtMost([]*Expr{a, b, c}, 1))
+	opt.AddSoft(a, "1", "prefs")
+	opt.AddSoft(b, "2", "prefs")
+	penalty := opt.AddSoft(c, "4", "prefs")
+
+	if result, err := opt.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	if value := penalty.Lower().String(); value != "3" {
+		t.Error("Expected penalty 3, got", value)
+	}
+	if value := opt.GetModel().Eval(c, true).String(); value != "true" {
+		t.Error("Expected c to hold, got", value)
+	}
+
+	// An assumption overrides the soft constraints within a scope.
+	opt.Push()
+	opt.Add(Not(c))
+	if result, err := opt.Check(a); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	if value := opt.GetModel().Eval(a, true).String(); value != "true" {
+		t.Error("Expected a to hold, got", value)
+	}
+	opt.Pop(1)
+	if result, err := opt.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	if value := penalty.Lower().String(); value != "3" {
+		t.Error("Expected penalty 3 after pop, got", value)
+	}
+}
+
+func TestOptimizerClose(t *testing.T) {
+	ctx := getContext()
+	opt := NewOptimizer(ctx)
+	opt.Close()
+	opt.Close()
+	expectUsageError(t, opt.Add(ctx.BoolVal(true)))
+	if opt.Maximize(ctx.IntConst("x")) != nil {
+		t.Error("Expected nil objective from closed optimizer")
+	}
+}
+
+func TestOptimizerFinalizer(t *testing.T) {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	NewOptimizer(ctx).Add(ctx.BoolConst("p"))
+	waitForDeletion(t, deleted)
+}
@@ -0,0 +1,395 @@
+package z3
+
+// #include <stdlib.h>
+// #include <z3.h>
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Parameters
+
+// ParamKind is the type of value expected by a parameter.
+type ParamKind int
+
+const (
+	ParamUint    ParamKind = C.Z3_PK_UINT
+	ParamBool    ParamKind = C.Z3_PK_BOOL
+	ParamDouble  ParamKind = C.Z3_PK_DOUBLE
+	ParamSymbol  ParamKind = C.Z3_PK_SYMBOL
+	ParamString  ParamKind = C.Z3_PK_STRING
+	ParamOther   ParamKind = C.Z3_PK_OTHER   // Internal kinds that cannot be set through the API.
+	ParamInvalid ParamKind = C.Z3_PK_INVALID // Unknown parameters.
+)
+
+func (kind ParamKind) String() string {
+	switch kind {
+	case ParamUint:
+		return "uint"
+	case ParamBool:
+		return "bool"
+	case ParamDouble:
+		return "double"
+	case ParamSymbol:
+		return "symbol"
+	case ParamString:
+		return "string"
+	case ParamOther:
+		return "other"
+	default:
+		return "invalid"
+	}
+}
+
+// accepts checks whether a parameter of this kind may be set to a value of
+// the given kind. String parameters are set using symbols.
+func (kind ParamKind) accepts(value ParamKind) bool {
+	return kind == value || kind == ParamString && value == ParamSymbol
+}
+
+// Params is a set of parameters for configuring solvers, tactics and other Z3
+// objects. Unlike a Config, it may be changed and applied after the context is
+// created.
+type Params struct {
+	z3val  C.Z3_params
+	ctx    *Context
+	closed bool
+	kinds  map[string]ParamKind // Kinds of the values set so far, by name.
+}
+
+// NewParams creates an empty parameter set. It returns nil if the context is
+// closed.
+func NewParams(ctx *Context) *Params {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	params := &Params{z3val: C.Z3_mk_params(ctx.z3val), ctx: ctx, kinds: map[string]ParamKind{}}
+	C.Z3_params_inc_ref(ctx.z3val, params.z3val)
+	ctx.acquire()
+	runtime.SetFinalizer(params, (*Params).finalize)
+	return params
+}
+
+func (params *Params) String() string {
+	if params.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_params_to_string(params.ctx.z3val, params.z3val))
+}
+
+// Close releases the parameter set. Calling Close more than once is a no-op.
+func (params *Params) Close() error {
+	if !params.closed {
+		params.closed = true
+		runtime.SetFinalizer(params, nil)
+		params.ctx.releasePending()
+		params.ctx.release(params.decRef())
+	}
+	return nil
+}
+
+func (params *Params) finalize() {
+	params.ctx.queueRelease(params.decRef())
+}
+
+func (params *Params) decRef() func() {
+	z3ctx, z3val := params.ctx.z3val, params.z3val
+	return func() {
+		C.Z3_params_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (params *Params) checkOpen() error {
+	if params.closed {
+		return params.ctx.usageError("params are closed")
+	}
+	return nil
+}
+
+// checkParams records and returns an error if params is nil, closed or
+// belongs to a different context.
+func (ctx *Context) checkParams(params *Params) error {
+	if params == nil {
+		return ctx.setError(&Error{InvalidArg, "nil params"})
+	}
+	if params.ctx != ctx {
+		return ctx.setError(&Error{InvalidArg, "params belong to a different context"})
+	}
+	return params.checkOpen()
+}
+
+// SetBool sets the parameter name to a bool value.
+func (params *Params) SetBool(name string, value bool) error {
+	if err := params.checkOpen(); err != nil {
+		return err
+	}
+	sym := params.ctx.NewStringSymbol(name)
+	C.Z3_params_set_bool(params.ctx.z3val, params.z3val, sym.z3val, getZ3Bool(value))
+	return params.record(name, ParamBool)
+}
+
+// SetUint sets the parameter name to an unsigned value.
+func (params *Params) SetUint(name string, value uint) error {
+	if err := params.checkOpen(); err != nil {
+		return err
+	}
+	sym := params.ctx.NewStringSymbol(name)
+	C.Z3_params_set_uint(params.ctx.z3val, params.z3val, sym.z3val, C.uint(value))
+	return params.record(name, ParamUint)
+}
+
+// SetDouble sets the parameter name to a floating-point value.
+func (params *Params) SetDouble(name string, value float64) error {
+	if err := params.checkOpen(); err != nil {
+		return err
+	}
+	sym := params.ctx.NewStringSymbol(name)
+	C.Z3_params_set_double(params.ctx.z3val, params.z3val, sym.z3val, C.double(value))
+	return params.record(name, ParamDouble)
+}
+
+// SetSymbol sets the parameter name to a symbol value, such as the name of a
+// strategy.
+func (params *Params) SetSymbol(name, value string) error {
+	if err := params.checkOpen(); err != nil {
+		return err
+	}
+	sym, val := params.ctx.NewStringSymbol(name), params.ctx.NewStringSymbol(value)
+	C.Z3_params_set_symbol(params.ctx.z3val, params.z3val, sym.z3val, val.z3val)
+	return params.record(name, ParamSymbol)
+}
+
+func (params *Params) record(name string, kind ParamKind) error {
+	if err := params.ctx.getError(); err != nil {
+		return err
+	}
+	params.kinds[name] = kind
+	return nil
+}
+
+// Validate checks that every parameter set so far is described by descrs and
+// has a value of the expected kind. The error for an invalid parameter lists
+// the valid parameters and their documentation. Module-qualified names such as
+// "smt.random_seed" that descrs does not cover are only checked to be known to
+// Z3: values of the wrong kind are not rejected for them. Looking such names up
+// briefly disables Z3 warnings for the whole process.
+func (params *Params) Validate(descrs *ParamDescrs) error {
+	if err := params.checkOpen(); err != nil {
+		return err
+	}
+	if err := params.ctx.checkParamDescrs(descrs); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(params.kinds))
+	for name := range params.kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		kind, expected := params.kinds[name], descrs.Kind(name)
+		if expected == ParamInvalid && strings.Contains(name, ".") {
+			if !knownModuleParam(name) {
+				return params.ctx.setError(&Error{InvalidArg, fmt.Sprintf("unknown module parameter %q", name)})
+			}
+			continue
+		}
+		var message string
+		if expected == ParamInvalid {
+			message = fmt.Sprintf("unknown parameter %q", name)
+		} else if !expected.accepts(kind) {
+			message = fmt.Sprintf("parameter %q expects a %s value, got %s", name, expected, kind)
+		} else {
+			continue
+		}
+		return params.ctx.setError(&Error{InvalidArg, message + "; valid parameters are:\n" + descrs.describe()})
+	}
+	return nil
+}
+
+// warningsMu serializes the lookups of knownModuleParam, which toggle the
+// process-wide Z3 warnings.
+var warningsMu sync.Mutex
+
+// knownModuleParam checks whether Z3 knows the module-qualified parameter name.
+// Looking up an unknown name prints a warning listing the module parameters,
+// so warnings are disabled meanwhile and then restored to the setting of the
+// "warning" global parameter. The toggle is process-wide: warnings from other
+// contexts raised during the lookup are lost too.
+func knownModuleParam(name string) bool {
+	cName, cWarning := C.CString(name), C.CString("warning")
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cWarning))
+
+	warningsMu.Lock()
+	defer warningsMu.Unlock()
+	var value C.Z3_string
+	warnings := C.Z3_global_param_get(cWarning, &value) != C.Z3_TRUE || C.GoString(value) != "false"
+	C.Z3_toggle_warning_messages(getZ3Bool(false))
+	known := C.Z3_global_param_get(cName, &value) == C.Z3_TRUE
+	C.Z3_toggle_warning_messages(getZ3Bool(warnings))
+	return known
+}
+
+// -----------------------------------------------------------------------------
+// Parameter descriptions
+
+// ParamDescrs describes the parameters accepted by a solver, tactic or other
+// Z3 object.
+type ParamDescrs struct {
+	z3val  C.Z3_param_descrs
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newParamDescrs(z3descrs C.Z3_param_descrs) *ParamDescrs {
+	if err := ctx.getError(); err != nil {
+		return nil
+	}
+	descrs := &ParamDescrs{z3val: z3descrs, ctx: ctx}
+	C.Z3_param_descrs_inc_ref(ctx.z3val, z3descrs)
+	ctx.acquire()
+	runtime.SetFinalizer(descrs, (*ParamDescrs).finalize)
+	return descrs
+}
+
+func (descrs *ParamDescrs) String() string {
+	if descrs.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_param_descrs_to_string(descrs.ctx.z3val, descrs.z3val))
+}
+
+// Close releases the descriptions. Calling Close more than once is a no-op.
+func (descrs *ParamDescrs) Close() error {
+	if !descrs.closed {
+		descrs.closed = true
+		runtime.SetFinalizer(descrs, nil)
+		descrs.ctx.releasePending()
+		descrs.ctx.release(descrs.decRef())
+	}
+	return nil
+}
+
+func (descrs *ParamDescrs) finalize() {
+	descrs.ctx.queueRelease(descrs.decRef())
+}
+
+func (descrs *ParamDescrs) decRef() func() {
+	z3ctx, z3val := descrs.ctx.z3val, descrs.z3val
+	return func() {
+		C.Z3_param_descrs_dec_ref(z3ctx, z3val)
+	}
+}
+
+// checkParamDescrs records and returns an error if descrs is nil, closed or
+// belongs to a different context.
+func (ctx *Context) checkParamDescrs(descrs *ParamDescrs) error {
+	if descrs == nil {
+		return ctx.setError(&Error{InvalidArg, "nil parameter descriptions"})
+	}
+	if descrs.ctx != ctx {
+		return ctx.setError(&Error{InvalidArg, "parameter descriptions belong to a different context"})
+	}
+	if descrs.closed {
+		return ctx.usageError("parameter descriptions are closed")
+	}
+	return nil
+}
+
+// Names returns the names of the described parameters.
+func (descrs *ParamDescrs) Names() []string {
+	if descrs.closed {
+		return nil
+	}
+	names := make([]string, C.Z3_param_descrs_size(descrs.ctx.z3val, descrs.z3val))
+	for i := range names {
+		names[i] = symbolString(descrs.ctx, C.Z3_param_descrs_get_name(descrs.ctx.z3val, descrs.z3val, C.uint(i)))
+	}
+	return names
+}
+
+// Kind returns the kind of the parameter name, or ParamInvalid if there is no
+// such parameter.
+func (descrs *ParamDescrs) Kind(name string) ParamKind {
+	if descrs.closed {
+		return ParamInvalid
+	}
+	sym := descrs.ctx.NewStringSymbol(name)
+	return ParamKind(C.Z3_param_descrs_get_kind(descrs.ctx.z3val, descrs.z3val, sym.z3val))
+}
+
+// Documentation returns the description of the parameter name.
+func (descrs *ParamDescrs) Documentation(name string) string {
+	if descrs.closed {
+		return ""
+	}
+	sym := descrs.ctx.NewStringSymbol(name)
+	return C.GoString(C.Z3_param_descrs_get_documentation(descrs.ctx.z3val, descrs.z3val, sym.z3val))
+}
+
+// describe lists the described parameters with their kinds and documentation,
+// one per line.
+func (descrs *ParamDescrs) describe() string {
+	var buf bytes.Buffer
+	for _, name := range descrs.Names() {
+		fmt.Fprintf(&buf, "  %s (%s) %s\n", name, descrs.Kind(name), descrs.Documentation(name))
+	}
+	return buf.String()
+}
+
+// paramDescrsCache keeps the parameter descriptions of a solver, optimizer or
+// fixedpoint engine. Z3 fails to describe their parameters once module
+// parameters such as "smt.random_seed" have been set, so the descriptions are
+// fetched before the first SetParams and kept until the owner is closed.
+type paramDescrsCache struct {
+	descrs *ParamDescrs
+}
+
+// get returns the cached descriptions, fetching them with describe on first
+// use.
+func (cache *paramDescrsCache) get(ctx *Context, describe func() C.Z3_param_descrs) *ParamDescrs {
+	if cache.descrs == nil {
+		cache.descrs = ctx.newParamDescrs(describe())
+	}
+	return cache.descrs
+}
+
+// paramDescrs returns a separate reference to the cached descriptions, which
+// the caller may close.
+func (cache *paramDescrsCache) paramDescrs(ctx *Context, describe func() C.Z3_param_descrs) *ParamDescrs {
+	descrs := cache.get(ctx, describe)
+	if descrs == nil {
+		return nil
+	}
+	return ctx.newParamDescrs(descrs.z3val)
+}
+
+// setParams validates params against the cached descriptions and applies them
+// using set.
+func (cache *paramDescrsCache) setParams(ctx *Context, params *Params, describe func() C.Z3_param_descrs, set func(C.Z3_params)) error {
+	if err := ctx.checkParams(params); err != nil {
+		return err
+	}
+	descrs := cache.get(ctx, describe)
+	if descrs == nil {
+		return ctx.LastError
+	}
+	if err := params.Validate(descrs); err != nil {
+		return err
+	}
+	set(params.z3val)
+	return ctx.getError()
+}
+
+func (cache *paramDescrsCache) close() {
+	if cache.descrs != nil {
+		cache.descrs.Close()
+	}
+}
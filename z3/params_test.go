@@ -0,0 +1,100 @@
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSolverParams(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	descrs := solver.ParamDescrs()
+	if descrs == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	if kind := descrs.Kind("timeout"); kind != ParamUint {
+		t.Error("Expected", ParamUint, "for timeout, got", kind)
+	}
+	if kind := descrs.Kind("no_such_param"); kind != ParamInvalid {
+		t.Error("Expected", ParamInvalid, "for unknown parameter, got", kind)
+	}
+	if doc := descrs.Documentation("timeout"); doc == "" {
+		t.Error("Expected documentation for timeout")
+	}
+
+	params := NewParams(ctx)
+	params.SetUint("timeout", 10000)
+	params.SetUint("smt.random_seed", 7)
+	if err := solver.SetParams(params); err != nil {
+		t.Error("Unexpected error:", err)
+	}
+
+	params.SetBool("timeut", true)
+	err := solver.SetParams(params)
+	expectErrorCode(t, err, InvalidArg)
+	if err == nil || !strings.Contains(err.Error(), `unknown parameter "timeut"`) ||
+		!strings.Contains(err.Error(), "timeout (uint)") {
+		t.Error("Expected error listing valid parameters, got", err)
+	}
+
+	params = NewParams(ctx)
+	params.SetBool("timeout", true)
+	err = solver.SetParams(params)
+	if err == nil || !strings.Contains(err.Error(), `parameter "timeout" expects a uint value, got bool`) {
+		t.Error("Expected kind mismatch error, got", err)
+	}
+
+	params = NewParams(ctx)
+	params.SetUint("smt.random_sed", 7)
+	err = solver.SetParams(params)
+	if err == nil || !strings.Contains(err.Error(), `unknown module parameter "smt.random_sed"`) {
+		t.Error("Expected unknown module parameter error, got", err)
+	}
+	params = NewParams(ctx)
+	params.SetSymbol("no_such_module.phase", "random")
+	expectErrorCode(t, solver.SetParams(params), InvalidArg)
+
+	// Module parameters break Z3_solver_get_param_descrs, so the solver must
+	// keep describing its parameters from before they were set.
+	if descrs := solver.ParamDescrs(); descrs == nil || descrs.Kind("timeout") != ParamUint {
+		t.Error("Expected descriptions after setting module parameters, got", ctx.LastError)
+	}
+}
+
+func TestTacticParams(t *testing.T) {
+	ctx := getContext()
+	simplify := NewTactic(ctx, "simplify")
+	params := NewParams(ctx)
+	params.SetSymbol("arith_lhs", "true")
+	if UsingParams(simplify, params) != nil {
+		t.Error("Expected nil tactic for a symbol given to a bool parameter")
+	}
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+
+	params = NewParams(ctx)
+	params.SetBool("arith_lhs", true)
+	params.SetDouble("no_such_param", 1.5)
+	if UsingParams(simplify, params) != nil {
+		t.Error("Expected nil tactic for an unknown parameter")
+	}
+}
+
+func TestOptimizerParams(t *testing.T) {
+	ctx := getContext()
+	opt := NewOptimizer(ctx)
+	if kind := opt.ParamDescrs().Kind("priority"); kind != ParamSymbol {
+		t.Error("Expected", ParamSymbol, "for priority, got", kind)
+	}
+	params := NewParams(ctx)
+	params.SetSymbol("maxsat_engine", "wmax")
+	if err := opt.SetParams(params); err != nil {
+		t.Error("Unexpected error:", err)
+	}
+}
+
+func TestParamDescrsFinalizer(t *testing.T) {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	NewSolver(ctx).ParamDescrs().Names()
+	waitForDeletion(t, deleted)
+}
@@ -0,0 +1,105 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import (
+	"fmt"
+	"math"
+)
+
+// -----------------------------------------------------------------------------
+// Pseudo-Boolean constraints
+
+// AtMost checks whether at most k of the Boolean expressions e are true. It
+// fails for an empty list, which has no context.
+func AtMost(e []*Expr, k uint) *Expr {
+	return cardinality(e, k, func(ctx *Context, n C.uint, asts *C.Z3_ast, k C.uint) C.Z3_ast {
+		return C.Z3_mk_atmost(ctx.z3val, n, asts, k)
+	})
+}
+
+// AtLeast checks whether at least k of the Boolean expressions e are true. It
+// fails for an empty list, which has no context.
+func AtLeast(e []*Expr, k uint) *Expr {
+	return cardinality(e, k, func(ctx *Context, n C.uint, asts *C.Z3_ast, k C.uint) C.Z3_ast {
+		return C.Z3_mk_atleast(ctx.z3val, n, asts, k)
+	})
+}
+
+// cardinality builds a cardinality constraint with mk. Z3 takes a C unsigned
+// bound, so values outside the 32-bit range are rejected rather than truncated.
+func cardinality(e []*Expr, k uint, mk func(*Context, C.uint, *C.Z3_ast, C.uint) C.Z3_ast) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	if uint64(k) > math.MaxUint32 {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf("bound %d is out of range", k)})
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := mk(ctx, C.uint(len(asts)), &asts[0], C.uint(k)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// PBLe checks whether the sum of the coefficients of the true Boolean
+// expressions e is at most k.
+func PBLe(e []*Expr, coeffs []int, k int) *Expr {
+	return pbConstraint(e, coeffs, k, func(ctx *Context, n C.uint, asts *C.Z3_ast, cs *C.int, k C.int) C.Z3_ast {
+		return C.Z3_mk_pble(ctx.z3val, n, asts, cs, k)
+	})
+}
+
+// PBGe checks whether the sum of the coefficients of the true Boolean
+// expressions e is at least k.
+func PBGe(e []*Expr, coeffs []int, k int) *Expr {
+	return pbConstraint(e, coeffs, k, func(ctx *Context, n C.uint, asts *C.Z3_ast, cs *C.int, k C.int) C.Z3_ast {
+		return C.Z3_mk_pbge(ctx.z3val, n, asts, cs, k)
+	})
+}
+
+// PBEq checks whether the sum of the coefficients of the true Boolean
+// expressions e is exactly k.
+func PBEq(e []*Expr, coeffs []int, k int) *Expr {
+	return pbConstraint(e, coeffs, k, func(ctx *Context, n C.uint, asts *C.Z3_ast, cs *C.int, k C.int) C.Z3_ast {
+		return C.Z3_mk_pbeq(ctx.z3val, n, asts, cs, k)
+	})
+}
+
+// pbConstraint validates the operands of a weighted pseudo-Boolean constraint
+// and builds it with mk. Z3 takes C int coefficients, so values outside the
+// 32-bit range are rejected rather than truncated.
+func pbConstraint(e []*Expr, coeffs []int, k int,
+	mk func(*Context, C.uint, *C.Z3_ast, *C.int, C.int) C.Z3_ast) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	if len(coeffs) != len(e) {
+		ctx.setError(&Error{InvalidArg,
+			fmt.Sprintf("%d coefficients given for %d expressions", len(coeffs), len(e))})
+		return nil
+	}
+	cs := make([]C.int, len(coeffs))
+	for i, c := range coeffs {
+		if c < math.MinInt32 || c > math.MaxInt32 {
+			ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("coefficient %d at position %d is out of range", c, i)})
+			return nil
+		}
+		cs[i] = C.int(c)
+	}
+	if k < math.MinInt32 || k > math.MaxInt32 {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf("bound %d is out of range", k)})
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := mk(ctx, C.uint(len(asts)), &asts[0], &cs[0], C.int(k)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
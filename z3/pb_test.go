@@ -0,0 +1,54 @@
+package z3
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCardinality(t *testing.T) {
+	ctx := getContext()
+	x := []*Expr{ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c"), ctx.BoolConst("d")}
+
+	solver := NewSolver(ctx)
+	solver.Add(AtMost(x, 2))
+	solver.Add(AtLeast(x, 2))
+	solver.Add(x[0], x[1])
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	solver.Add(Or(x[2], x[3]))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+
+	if k := uint64(math.MaxUint32) + 1; uint64(uint(k)) == k {
+		if e := AtMost(x, uint(k)); e != nil {
+			t.Error("Expected nil for out of range bound, got", e)
+		}
+		expectErrorCode(t, ctx.LastError, InvalidArg)
+		if e := AtLeast(x, uint(k)); e != nil {
+			t.Error("Expected nil for out of range bound, got", e)
+		}
+		expectErrorCode(t, ctx.LastError, InvalidArg)
+	}
+}
+
+func TestPseudoBoolean(t *testing.T) {
+	ctx := getContext()
+	x := []*Expr{ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")}
+	coeffs := []int{2, 3, 5}
+	checkModel(t, ctx, []*Expr{
+		PBEq(x, coeffs, 7),
+		PBLe(x, coeffs, 8),
+		PBGe(x, coeffs, 6),
+	}, x, []string{"true", "false", "true"})
+
+	if e := PBLe(x, coeffs[:2], 1); e != nil {
+		t.Error("Expected nil for mismatched coefficients")
+	}
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+	if e := PBGe(x, []int{1, 1, 1 << 40}, 1); e != nil {
+		t.Error("Expected nil for out of range coefficient")
+	}
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+}
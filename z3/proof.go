@@ -0,0 +1,217 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import "fmt"
+
+// -----------------------------------------------------------------------------
+// Proofs
+
+// ProofRule identifies the inference rule that justifies a proof step.
+type ProofRule int
+
+const (
+	ProofUndef            ProofRule = C.Z3_OP_PR_UNDEF // Not a proof step.
+	ProofTrue             ProofRule = C.Z3_OP_PR_TRUE
+	ProofAsserted         ProofRule = C.Z3_OP_PR_ASSERTED
+	ProofGoal             ProofRule = C.Z3_OP_PR_GOAL
+	ProofModusPonens      ProofRule = C.Z3_OP_PR_MODUS_PONENS
+	ProofReflexivity      ProofRule = C.Z3_OP_PR_REFLEXIVITY
+	ProofSymmetry         ProofRule = C.Z3_OP_PR_SYMMETRY
+	ProofTransitivity     ProofRule = C.Z3_OP_PR_TRANSITIVITY
+	ProofTransitivityStar ProofRule = C.Z3_OP_PR_TRANSITIVITY_STAR
+	ProofMonotonicity     ProofRule = C.Z3_OP_PR_MONOTONICITY
+	ProofQuantIntro       ProofRule = C.Z3_OP_PR_QUANT_INTRO
+	ProofBind             ProofRule = C.Z3_OP_PR_BIND
+	ProofDistributivity   ProofRule = C.Z3_OP_PR_DISTRIBUTIVITY
+	ProofAndElim          ProofRule = C.Z3_OP_PR_AND_ELIM
+	ProofNotOrElim        ProofRule = C.Z3_OP_PR_NOT_OR_ELIM
+	ProofRewrite          ProofRule = C.Z3_OP_PR_REWRITE
+	ProofRewriteStar      ProofRule = C.Z3_OP_PR_REWRITE_STAR
+	ProofPullQuant        ProofRule = C.Z3_OP_PR_PULL_QUANT
+	ProofPushQuant        ProofRule = C.Z3_OP_PR_PUSH_QUANT
+	ProofElimUnusedVars   ProofRule = C.Z3_OP_PR_ELIM_UNUSED_VARS
+	ProofDer              ProofRule = C.Z3_OP_PR_DER
+	ProofQuantInst        ProofRule = C.Z3_OP_PR_QUANT_INST
+	ProofHypothesis       ProofRule = C.Z3_OP_PR_HYPOTHESIS
+	ProofLemma            ProofRule = C.Z3_OP_PR_LEMMA
+	ProofUnitResolution   ProofRule = C.Z3_OP_PR_UNIT_RESOLUTION
+	ProofIffTrue          ProofRule = C.Z3_OP_PR_IFF_TRUE
+	ProofIffFalse         ProofRule = C.Z3_OP_PR_IFF_FALSE
+	ProofCommutativity    ProofRule = C.Z3_OP_PR_COMMUTATIVITY
+	ProofDefAxiom         ProofRule = C.Z3_OP_PR_DEF_AXIOM
+	ProofDefIntro         ProofRule = C.Z3_OP_PR_DEF_INTRO
+	ProofApplyDef         ProofRule = C.Z3_OP_PR_APPLY_DEF
+	ProofIffOEq           ProofRule = C.Z3_OP_PR_IFF_OEQ
+	ProofNNFPos           ProofRule = C.Z3_OP_PR_NNF_POS
+	ProofNNFNeg           ProofRule = C.Z3_OP_PR_NNF_NEG
+	ProofSkolemize        ProofRule = C.Z3_OP_PR_SKOLEMIZE
+	ProofModusPonensOEq   ProofRule = C.Z3_OP_PR_MODUS_PONENS_OEQ
+	ProofTheoryLemma      ProofRule = C.Z3_OP_PR_TH_LEMMA
+	ProofHyperResolve     ProofRule = C.Z3_OP_PR_HYPER_RESOLVE
+)
+
+var proofRuleNames = map[ProofRule]string{
+	ProofTrue:             "true-axiom",
+	ProofAsserted:         "asserted",
+	ProofGoal:             "goal",
+	ProofModusPonens:      "mp",
+	ProofReflexivity:      "refl",
+	ProofSymmetry:         "symm",
+	ProofTransitivity:     "trans",
+	ProofTransitivityStar: "trans*",
+	ProofMonotonicity:     "monotonicity",
+	ProofQuantIntro:       "quant-intro",
+	ProofBind:             "proof-bind",
+	ProofDistributivity:   "distributivity",
+	ProofAndElim:          "and-elim",
+	ProofNotOrElim:        "not-or-elim",
+	ProofRewrite:          "rewrite",
+	ProofRewriteStar:      "rewrite*",
+	ProofPullQuant:        "pull-quant",
+	ProofPushQuant:        "push-quant",
+	ProofElimUnusedVars:   "elim-unused",
+	ProofDer:              "der",
+	ProofQuantInst:        "quant-inst",
+	ProofHypothesis:       "hypothesis",
+	ProofLemma:            "lemma",
+	ProofUnitResolution:   "unit-resolution",
+	ProofIffTrue:          "iff-true",
+	ProofIffFalse:         "iff-false",
+	ProofCommutativity:    "commutativity",
+	ProofDefAxiom:         "def-axiom",
+	ProofDefIntro:         "intro-def",
+	ProofApplyDef:         "apply-def",
+	ProofIffOEq:           "iff~",
+	ProofNNFPos:           "nnf-pos",
+	ProofNNFNeg:           "nnf-neg",
+	ProofSkolemize:        "sk",
+	ProofModusPonensOEq:   "mp~",
+	ProofTheoryLemma:      "th-lemma",
+	ProofHyperResolve:     "hyper-res",
+}
+
+// String returns the name Z3 uses for the rule when printing proofs.
+func (rule ProofRule) String() string {
+	if name, ok := proofRuleNames[rule]; ok {
+		return name
+	}
+	return fmt.Sprintf("<proof rule %d>", int(rule))
+}
+
+// Proof returns the proof of unsatisfiability found by the last check. Proof
+// generation must be enabled by setting the "proof" parameter of the Config
+// used to create the context.
+func (solver *Solver) Proof() *Expr {
+	if err := solver.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_solver_get_proof(solver.ctx.z3val, solver.z3val), solver.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return solver.ctx.newExpr(z3ast)
+}
+
+// ProofRule returns the rule that justifies the proof step, or ProofUndef if
+// the expression is not a proof step. Steps are recognized by their proof
+// sort, so rules without a constant above are returned as well.
+func (expr *Expr) ProofRule() ProofRule {
+	if C.Z3_is_app(expr.ctx.z3val, expr.z3val) != C.Z3_TRUE {
+		return ProofUndef
+	}
+	z3decl := C.Z3_get_app_decl(expr.ctx.z3val, C.Z3_to_app(expr.ctx.z3val, expr.z3val))
+	if !isProofSort(expr.ctx, C.Z3_get_range(expr.ctx.z3val, z3decl)) {
+		return ProofUndef
+	}
+	return ProofRule(C.Z3_get_decl_kind(expr.ctx.z3val, z3decl))
+}
+
+// isProofSort checks whether z3sort is the sort of proofs, which the API
+// reports as an unknown sort named "Proof".
+func isProofSort(ctx *Context, z3sort C.Z3_sort) bool {
+	return C.Z3_get_sort_kind(ctx.z3val, z3sort) == C.Z3_UNKNOWN_SORT &&
+		symbolString(ctx, C.Z3_get_sort_name(ctx.z3val, z3sort)) == "Proof"
+}
+
+// IsProof checks whether the expression is a proof step.
+func (expr *Expr) IsProof() bool {
+	return expr.ProofRule() != ProofUndef
+}
+
+func (expr *Expr) checkProof() error {
+	if !expr.IsProof() {
+		return expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a proof", expr)})
+	}
+	return nil
+}
+
+// ProofPremises returns the proofs of the premises of the proof step.
+func (expr *Expr) ProofPremises() []*Expr {
+	if err := expr.checkProof(); err != nil {
+		return nil
+	}
+	z3app := C.Z3_to_app(expr.ctx.z3val, expr.z3val)
+	premises := make([]*Expr, C.Z3_get_app_num_args(expr.ctx.z3val, z3app)-1)
+	for i := range premises {
+		premises[i] = expr.ctx.newExpr(C.Z3_get_app_arg(expr.ctx.z3val, z3app, C.uint(i)))
+	}
+	return premises
+}
+
+// ProofConclusion returns the formula established by the proof step. The
+// conclusion of a refutation is false.
+func (expr *Expr) ProofConclusion() *Expr {
+	if err := expr.checkProof(); err != nil {
+		return nil
+	}
+	z3app := C.Z3_to_app(expr.ctx.z3val, expr.z3val)
+	n := C.Z3_get_app_num_args(expr.ctx.z3val, z3app)
+	return expr.ctx.newExpr(C.Z3_get_app_arg(expr.ctx.z3val, z3app, n-1))
+}
+
+// ProofTheory returns the name of the theory, such as "arith", that justifies
+// a theory lemma, or the empty string if it is not recorded.
+func (expr *Expr) ProofTheory() string {
+	if expr.ProofRule() != ProofTheoryLemma {
+		return ""
+	}
+	z3decl := C.Z3_get_app_decl(expr.ctx.z3val, C.Z3_to_app(expr.ctx.z3val, expr.z3val))
+	if C.Z3_get_decl_num_parameters(expr.ctx.z3val, z3decl) == 0 ||
+		C.Z3_get_decl_parameter_kind(expr.ctx.z3val, z3decl, 0) != C.Z3_PARAMETER_SYMBOL {
+		return ""
+	}
+	return symbolString(expr.ctx, C.Z3_get_decl_symbol_parameter(expr.ctx.z3val, z3decl, 0))
+}
+
+// ProofSteps returns the distinct steps of the proof, each after the proofs
+// of its premises, ending with the proof itself. Steps shared by several
+// premises appear once.
+func (expr *Expr) ProofSteps() []*Expr {
+	if err := expr.checkProof(); err != nil {
+		return nil
+	}
+	var steps []*Expr
+	visited := map[C.uint]bool{}
+	type frame struct {
+		step     *Expr
+		premises []*Expr
+	}
+	stack := []frame{{expr, expr.ProofPremises()}}
+	visited[C.Z3_get_ast_id(expr.ctx.z3val, expr.z3val)] = true
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.premises) == 0 {
+			steps = append(steps, top.step)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := top.premises[0]
+		top.premises = top.premises[1:]
+		if id := C.Z3_get_ast_id(next.ctx.z3val, next.z3val); !visited[id] {
+			visited[id] = true
+			stack = append(stack, frame{next, next.ProofPremises()})
+		}
+	}
+	return steps
+}
@@ -0,0 +1,71 @@
+package z3
+
+import "testing"
+
+func TestProof(t *testing.T) {
+	config := NewConfig()
+	config.SetParamBool("proof", true)
+	ctx := NewContext(config)
+	config.Close()
+	defer ctx.Close()
+
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	p := ctx.BoolConst("p")
+	solver := NewSolver(ctx)
+	defer solver.Close()
+	solver.Add(Implies(p, Gt(x, y)), p, Gt(y, x))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Fatal("Expected", LFalse, "got", result, err)
+	}
+	proof := solver.Proof()
+	if proof == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	if conclusion := proof.ProofConclusion(); conclusion.String() != "false" {
+		t.Error("Expected refutation, got", conclusion)
+	}
+
+	steps := proof.ProofSteps()
+	if len(steps) == 0 || steps[len(steps)-1].String() != proof.String() {
+		t.Fatal("Expected proof to be the last step")
+	}
+	rules := map[ProofRule]int{}
+	for _, step := range steps {
+		rule := step.ProofRule()
+		if rule == ProofUndef {
+			t.Fatal("Unexpected non-proof step", step)
+		}
+		rules[rule]++
+		if rule == ProofModusPonens {
+			// From p and p => q (or p = q), conclude q.
+			premises := step.ProofPremises()
+			if len(premises) != 2 {
+				t.Error("Expected 2 premises for", rule, "got", len(premises))
+			}
+		}
+		if rule == ProofTheoryLemma && step.ProofTheory() == "" {
+			t.Error("Expected a theory for", step)
+		}
+	}
+	if rules[ProofAsserted] != 3 {
+		t.Error("Expected 3 asserted steps, got", rules[ProofAsserted], rules)
+	}
+	if ProofModusPonens.String() != "mp" || ProofUnitResolution.String() != "unit-resolution" {
+		t.Error("Unexpected rule names", ProofModusPonens, ProofUnitResolution)
+	}
+
+	if x.IsProof() || x.ProofPremises() != nil {
+		t.Error("Expected x not to be a proof")
+	}
+	expectErrorCode(t, ctx.LastError, InvalidArg)
+}
+
+func TestProofDisabled(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	solver.Add(ctx.BoolVal(false))
+	solver.Check()
+	if proof := solver.Proof(); proof != nil {
+		t.Error("Expected no proof without proof generation, got", proof)
+	}
+}
@@ -0,0 +1,57 @@
+package z3
+
+import "testing"
+
+func TestForAll(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	f := ctx.Constant("f", ctx.ArraySort(intSort, intSort))
+	x := ctx.IntConst("x")
+	fx := Select(f, x)
+	axiom := ForAll([]*Expr{x}, Gt(fx, x), NewPattern(fx))
+	if !axiom.IsForAll() || axiom.IsExists() {
+		t.Fatal("Expected universal quantifier, got", axiom, ctx.LastError)
+	}
+	if names := axiom.QuantifierBoundNames(); len(names) != 1 || names[0] != "x" {
+		t.Error("Expected bound names [x], got", names)
+	}
+	if sorts := axiom.QuantifierBoundSorts(); len(sorts) != 1 || sorts[0].SortKind() != IntSort {
+		t.Error("Expected bound sorts [Int], got", sorts)
+	}
+	if patterns := axiom.QuantifierPatterns(); len(patterns) != 1 || len(patterns[0].Terms()) != 1 {
+		t.Error("Expected a single pattern, got", patterns)
+	}
+
+	solver := NewSolver(ctx)
+	solver.Add(axiom, Lt(Select(f, ctx.IntVal(3)), ctx.IntVal(3)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Error("Expected", LFalse, "got", result, err)
+	}
+}
+
+func TestQuantifierBound(t *testing.T) {
+	ctx := getContext()
+	intSort := ctx.IntSort()
+	y := ctx.Bound(0, intSort)
+	q := Quantifier(false, 5, []string{"y"}, []*Sort{intSort}, nil, nil, Gt(y, ctx.IntVal(0)))
+	if !q.IsExists() {
+		t.Fatal("Expected existential quantifier, got", q, ctx.LastError)
+	}
+	if weight, err := q.QuantifierWeight(); weight != 5 || err != nil {
+		t.Error("Expected weight 5, got", weight, err)
+	}
+	body := q.QuantifierBody()
+	if body == nil || body.String() != "(> (:var 0) 0)" {
+		t.Error("Expected body (> (:var 0) 0), got", body)
+	}
+	if index, err := y.BoundIndex(); index != 0 || err != nil {
+		t.Error("Expected index 0, got", index, err)
+	}
+
+	if _, err := y.QuantifierWeight(); err == nil {
+		t.Error("Expected error for non-quantifier")
+	}
+	if expr := ForAll(nil, ctx.BoolVal(true)); expr != nil {
+		t.Error("Expected nil expression, got", expr)
+	}
+}
@@ -0,0 +1,631 @@
+package z3
+
+// #include <stdlib.h>
+// #include <z3.h>
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Sequence and string sorts
+
+// StringSort returns the sort of strings, which are sequences of characters.
+func (ctx *Context) StringSort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_string_sort(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// SeqSort returns the sort of sequences of elements of the given sort.
+func (ctx *Context) SeqSort(elem *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(elem); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_seq_sort(ctx.z3val, elem.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// ReSort returns the sort of regular expressions over the given sequence sort.
+func (ctx *Context) ReSort(seq *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(seq); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_re_sort(ctx.z3val, seq.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// IsStringSort checks whether the sort is the string sort.
+func (sort *Sort) IsStringSort() bool {
+	return C.Z3_is_string_sort(sort.ctx.z3val, sort.z3sort()) == C.Z3_TRUE
+}
+
+// IsSeqSort checks whether the sort is a sequence sort, including strings.
+func (sort *Sort) IsSeqSort() bool {
+	return C.Z3_is_seq_sort(sort.ctx.z3val, sort.z3sort()) == C.Z3_TRUE
+}
+
+// IsReSort checks whether the sort is a regular expression sort.
+func (sort *Sort) IsReSort() bool {
+	return C.Z3_is_re_sort(sort.ctx.z3val, sort.z3sort()) == C.Z3_TRUE
+}
+
+// -----------------------------------------------------------------------------
+// Sequences and strings
+
+func (ctx *Context) StringConst(name string) *Expr {
+	return ctx.Constant(name, ctx.StringSort())
+}
+
+// StringVal returns the string literal s. Characters outside printable ASCII
+// are escaped, so that any valid UTF-8 string is represented exactly.
+func (ctx *Context) StringVal(s string) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if !utf8.ValidString(s) {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf("invalid UTF-8 string %q", s)})
+		return nil
+	}
+	cValue := C.CString(escapeString(s))
+	defer C.free(unsafe.Pointer(cValue))
+
+	z3ast, err := C.Z3_mk_string(ctx.z3val, cValue), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// escapeString encodes s in the SMT-LIB string literal syntax understood by
+// Z3_mk_string, where \u{...} denotes a character by its code point.
+func escapeString(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		if r >= ' ' && r <= '~' && r != '\\' {
+			buf.WriteRune(r)
+		} else {
+			fmt.Fprintf(&buf, "\\u{%x}", r)
+		}
+	}
+	return buf.String()
+}
+
+// unescapeChar decodes a single character printed by Z3_get_string, which is
+// either a printable ASCII character or an escape such as \u{e9}.
+func unescapeChar(s string) (rune, error) {
+	if len(s) == 1 {
+		return rune(s[0]), nil
+	}
+	var digits string
+	switch {
+	case strings.HasPrefix(s, `\u{`) && strings.HasSuffix(s, "}"):
+		digits = s[3 : len(s)-1]
+	case strings.HasPrefix(s, `\u`) && len(s) == 6, strings.HasPrefix(s, `\x`) && len(s) == 4:
+		digits = s[2:]
+	default:
+		return 0, fmt.Errorf("invalid character %q", s)
+	}
+	r, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid escape %q", s)
+	}
+	return rune(r), nil
+}
+
+// IsString checks whether the expression is a string literal.
+func (expr *Expr) IsString() bool {
+	return C.Z3_is_string(expr.ctx.z3val, expr.z3val) == C.Z3_TRUE
+}
+
+// StringValue returns the value of a string literal, such as those obtained
+// from Model.Eval.
+func (expr *Expr) StringValue() (string, error) {
+	if !expr.IsString() {
+		return "", expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a string literal", expr)})
+	}
+	printed := C.GoString(C.Z3_get_string(expr.ctx.z3val, expr.z3val))
+	if err := expr.ctx.getError(); err != nil {
+		return "", err
+	}
+	if !strings.Contains(printed, `\`) {
+		return printed, nil
+	}
+	var length C.uint
+	raw := C.Z3_get_lstring(expr.ctx.z3val, expr.z3val, &length)
+	if err := expr.ctx.getError(); err != nil {
+		return "", err
+	}
+	value, err := decodeString(printed, C.GoStringN(raw, C.int(length)))
+	if err != nil {
+		return "", expr.ctx.setError(&Error{InvalidArg, err.Error()})
+	}
+	return value, nil
+}
+
+// decodeString decodes a string literal printed by Z3_get_string, which
+// escapes the characters outside printable ASCII but not backslashes, so a
+// literal "\u{e9}" prints the same as "é". The raw form of the literal given
+// by Z3_get_lstring tells them apart: it keeps the characters below 256 as
+// single bytes, and only escapes the others, with a doubled backslash and
+// mangled digits.
+func decodeString(printed, raw string) (string, error) {
+	var buf strings.Builder
+	for printed != "" {
+		if raw == "" {
+			return "", fmt.Errorf("string literal %q is longer than its raw form", printed)
+		}
+		escaped := printed[0] == '\\' &&
+			(raw[0] != '\\' || strings.HasPrefix(printed, `\u`) && strings.HasPrefix(raw, `\\`))
+		if !escaped {
+			if printed[0] != raw[0] {
+				return "", fmt.Errorf("string literal %q does not match its raw form %q", printed, raw)
+			}
+			buf.WriteByte(printed[0])
+			printed, raw = printed[1:], raw[1:]
+			continue
+		}
+		end := strings.IndexByte(printed, '}')
+		if end < 0 {
+			return "", fmt.Errorf("invalid escape in string literal %q", printed)
+		}
+		r, err := unescapeChar(printed[:end+1])
+		if err != nil {
+			return "", err
+		}
+		if r < 256 {
+			if raw[0] != byte(r) {
+				return "", fmt.Errorf("string literal %q does not match its raw form %q", printed, raw)
+			}
+			raw = raw[1:]
+		} else if rawEnd := strings.IndexByte(raw, '}'); rawEnd >= 0 {
+			raw = raw[rawEnd+1:]
+		} else {
+			return "", fmt.Errorf("invalid escape in raw string literal %q", raw)
+		}
+		buf.WriteRune(r)
+		printed = printed[end+1:]
+	}
+	if raw != "" {
+		return "", fmt.Errorf("raw string literal %q is longer than its printed form", raw)
+	}
+	return buf.String(), nil
+}
+
+// EmptySeq returns the empty sequence of the given sequence sort.
+func (ctx *Context) EmptySeq(sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_empty(ctx.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqUnit returns the sequence containing the single element a.
+func SeqUnit(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_unit(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqConcat concatenates one or more sequences.
+func SeqConcat(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_seq_concat(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqLength returns the length of the sequence s.
+func SeqLength(s *Expr) *Expr {
+	ctx := operandContext(s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_length(ctx.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqContains checks whether sub occurs in the sequence s.
+func SeqContains(s, sub *Expr) *Expr {
+	ctx := operandContext(s, sub)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_contains(ctx.z3val, s.z3val, sub.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqPrefix checks whether prefix is a prefix of the sequence s.
+func SeqPrefix(prefix, s *Expr) *Expr {
+	ctx := operandContext(prefix, s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_prefix(ctx.z3val, prefix.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqSuffix checks whether suffix is a suffix of the sequence s.
+func SeqSuffix(suffix, s *Expr) *Expr {
+	ctx := operandContext(suffix, s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_suffix(ctx.z3val, suffix.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqIndexOf returns the index of the first occurrence of sub in the sequence
+// s at or after offset, or -1 if there is none.
+func SeqIndexOf(s, sub, offset *Expr) *Expr {
+	ctx := operandContext(s, sub, offset)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_index(ctx.z3val, s.z3val, sub.z3val, offset.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqLastIndexOf returns the index of the last occurrence of sub in the
+// sequence s, or -1 if there is none.
+func SeqLastIndexOf(s, sub *Expr) *Expr {
+	ctx := operandContext(s, sub)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_last_index(ctx.z3val, s.z3val, sub.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqReplace replaces the first occurrence of src in the sequence s with dst.
+func SeqReplace(s, src, dst *Expr) *Expr {
+	ctx := operandContext(s, src, dst)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_replace(ctx.z3val, s.z3val, src.z3val, dst.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqExtract returns the subsequence of s of the given length starting at
+// offset.
+func SeqExtract(s, offset, length *Expr) *Expr {
+	ctx := operandContext(s, offset, length)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_extract(ctx.z3val, s.z3val, offset.z3val, length.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqAt returns the unit sequence holding the element of s at index i, or the
+// empty sequence if i is out of bounds.
+func SeqAt(s, i *Expr) *Expr {
+	ctx := operandContext(s, i)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_at(ctx.z3val, s.z3val, i.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqNth returns the element of s at index i, which is unspecified if i is
+// out of bounds.
+func SeqNth(s, i *Expr) *Expr {
+	ctx := operandContext(s, i)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_nth(ctx.z3val, s.z3val, i.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// StrToInt converts a string of decimal digits to an integer, or to -1 if it
+// contains other characters.
+func StrToInt(s *Expr) *Expr {
+	ctx := operandContext(s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_str_to_int(ctx.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// IntToStr converts a non-negative integer to its decimal representation, or
+// to the empty string for negative integers.
+func IntToStr(i *Expr) *Expr {
+	ctx := operandContext(i)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_int_to_str(ctx.z3val, i.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// StrLt checks whether the string a is lexicographically smaller than b.
+func StrLt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_str_lt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// StrLe checks whether the string a is lexicographically smaller than or equal
+// to b.
+func StrLe(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_str_le(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// -----------------------------------------------------------------------------
+// Regular expressions
+
+// SeqToRe returns the regular expression matching exactly the sequence s.
+func SeqToRe(s *Expr) *Expr {
+	ctx := operandContext(s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_to_re(ctx.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SeqInRe checks whether the sequence s matches the regular expression re.
+func SeqInRe(s, re *Expr) *Expr {
+	ctx := operandContext(s, re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_seq_in_re(ctx.z3val, s.z3val, re.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReStar returns the Kleene closure of re.
+func ReStar(re *Expr) *Expr {
+	ctx := operandContext(re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_star(ctx.z3val, re.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// RePlus returns the Kleene plus of re.
+func RePlus(re *Expr) *Expr {
+	ctx := operandContext(re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_plus(ctx.z3val, re.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReOption matches re or the empty sequence.
+func ReOption(re *Expr) *Expr {
+	ctx := operandContext(re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_option(ctx.z3val, re.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReComplement matches the sequences not matched by re.
+func ReComplement(re *Expr) *Expr {
+	ctx := operandContext(re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_complement(ctx.z3val, re.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReLoop matches between lo and hi repetitions of re, or at least lo
+// repetitions if hi is 0.
+func ReLoop(re *Expr, lo, hi uint) *Expr {
+	ctx := operandContext(re)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_loop(ctx.z3val, re.z3val, C.uint(lo), C.uint(hi)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReRange matches the single characters between the unit strings lo and hi,
+// inclusive.
+func ReRange(lo, hi *Expr) *Expr {
+	ctx := operandContext(lo, hi)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_range(ctx.z3val, lo.z3val, hi.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReUnion matches the sequences matched by any of one or more regular
+// expressions.
+func ReUnion(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_re_union(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReConcat matches the concatenations of the sequences matched by one or more
+// regular expressions.
+func ReConcat(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_re_concat(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReIntersect matches the sequences matched by all of one or more regular
+// expressions.
+func ReIntersect(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_re_intersect(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReEmpty returns the regular expression of the given sort matching nothing.
+func (ctx *Context) ReEmpty(sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_empty(ctx.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ReFull returns the regular expression of the given sort matching every
+// sequence.
+func (ctx *Context) ReFull(sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_re_full(ctx.z3val, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
@@ -0,0 +1,72 @@
+package z3
+
+import "testing"
+
+func TestStringValue(t *testing.T) {
+	ctx := getContext()
+	for _, s := range []string{
+		"", "hello", "quote\" backslash\\ u{41}", "héllo, 世界\n\x00",
+		"a\\u{41}b", "\\x41", "\\u0041", "\\u{e9} é", "\\", "\\u{",
+		"Ŝ\\", "\\u{15c} Ŝ", "\\\\u{100}Ā", "\\😀\x7f",
+	} {
+		if value, err := ctx.StringVal(s).StringValue(); value != s || err != nil {
+			t.Errorf("Expected %q, got %q %v", s, value, err)
+		}
+		x := ctx.StringConst("x")
+		solver := NewSolver(ctx)
+		solver.Add(Eq(x, ctx.StringVal(s)))
+		if result, err := solver.Check(); result != LTrue || err != nil {
+			t.Fatal("Expected", LTrue, "got", result, err)
+		}
+		value, err := solver.GetModel().Eval(x, true).StringValue()
+		if err != nil {
+			t.Error("Unexpected error:", err)
+		} else if value != s {
+			t.Errorf("Expected %q, got %q", s, value)
+		}
+	}
+	if _, err := ctx.StringConst("x").StringValue(); err == nil {
+		t.Error("Expected error for non-literal")
+	}
+}
+
+func TestStringOperations(t *testing.T) {
+	ctx := getContext()
+	s, n := ctx.StringConst("s"), ctx.IntConst("n")
+	checkModel(t, ctx, []*Expr{
+		Eq(s, SeqConcat(ctx.StringVal("id-"), IntToStr(n))),
+		Eq(SeqLength(s), ctx.IntVal(6)),
+		SeqSuffix(ctx.StringVal("42"), s),
+		SeqContains(s, ctx.StringVal("-1")),
+	}, []*Expr{
+		n,
+		SeqIndexOf(s, ctx.StringVal("4"), ctx.IntVal(0)),
+		StrToInt(SeqExtract(s, ctx.IntVal(3), ctx.IntVal(2))),
+		SeqReplace(s, ctx.StringVal("id"), SeqAt(s, ctx.IntVal(4))),
+		SeqPrefix(ctx.StringVal("id"), s),
+	}, []string{"142", "4", "14", `"4-142"`, "true"})
+}
+
+func TestRegex(t *testing.T) {
+	ctx := getContext()
+	s := ctx.StringConst("s")
+	lower := ReRange(ctx.StringVal("a"), ctx.StringVal("z"))
+	email := ReConcat(RePlus(lower), SeqToRe(ctx.StringVal("@")), ReLoop(lower, 2, 3))
+	for _, test := range []struct {
+		value    string
+		expected LiftedBool
+	}{
+		{"ab@cd", LTrue},
+		{"ab@c", LFalse},
+		{"@cde", LFalse},
+	} {
+		solver := NewSolver(ctx)
+		solver.Add(SeqInRe(s, email), Eq(s, ctx.StringVal(test.value)))
+		if result, err := solver.Check(); result != test.expected || err != nil {
+			t.Error("Expected", test.expected, "for", test.value, "got", result, err)
+		}
+	}
+	if re := ReUnion(lower, SeqToRe(ctx.IntVal(1))); re != nil {
+		t.Error("Expected nil expression, got", re)
+	}
+}
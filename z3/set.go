@@ -0,0 +1,177 @@
+package z3
+
+// #include <z3.h>
+import "C"
+
+// -----------------------------------------------------------------------------
+// Set sorts
+
+// SetSort returns the sort of sets of elements of the given sort. Sets are
+// represented as arrays from elem to Bool.
+func (ctx *Context) SetSort(elem *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(elem); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_set_sort(ctx.z3val, elem.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+// -----------------------------------------------------------------------------
+// Set operations
+
+// EmptySet returns the set containing no elements of the given sort.
+func (ctx *Context) EmptySet(domain *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(domain); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_empty_set(ctx.z3val, domain.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// FullSet returns the set containing every element of the given sort.
+func (ctx *Context) FullSet(domain *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(domain); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_full_set(ctx.z3val, domain.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetAdd returns the set s with elem added.
+func SetAdd(s, elem *Expr) *Expr {
+	ctx := operandContext(s, elem)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_add(ctx.z3val, s.z3val, elem.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetDel returns the set s with elem removed.
+func SetDel(s, elem *Expr) *Expr {
+	ctx := operandContext(s, elem)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_del(ctx.z3val, s.z3val, elem.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetUnion returns the union of one or more sets.
+func SetUnion(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_set_union(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetIntersect returns the intersection of one or more sets.
+func SetIntersect(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_set_intersect(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetDifference returns the elements of a that are not in b.
+func SetDifference(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_difference(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetComplement returns the elements that are not in s.
+func SetComplement(s *Expr) *Expr {
+	ctx := operandContext(s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_complement(ctx.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetMember checks whether elem is a member of s.
+func SetMember(elem, s *Expr) *Expr {
+	ctx := operandContext(elem, s)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_member(ctx.z3val, elem.z3val, s.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetSubset checks whether a is a subset of b.
+func SetSubset(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_subset(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SetHasSize checks whether the finite set s has exactly k elements, where k
+// is an integer expression. Not every Z3 release supports set cardinality;
+// where it is missing, SetHasSize records an Exception and returns nil.
+func SetHasSize(s, k *Expr) *Expr {
+	ctx := operandContext(s, k)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_set_has_size(ctx.z3val, s.z3val, k.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
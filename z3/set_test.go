@@ -0,0 +1,47 @@
+package z3
+
+import "testing"
+
+func TestSetOperations(t *testing.T) {
+	ctx := getContext()
+	userSort := ctx.UninterpretedSort("User")
+	setSort := ctx.SetSort(userSort)
+	alice, bob := ctx.Constant("alice", userSort), ctx.Constant("bob", userSort)
+	admins, readers := ctx.Constant("admins", setSort), ctx.Constant("readers", setSort)
+
+	solver := NewSolver(ctx)
+	solver.Add(Eq(admins, SetAdd(ctx.EmptySet(userSort), alice)))
+	solver.Add(Eq(readers, SetUnion(admins, SetAdd(ctx.EmptySet(userSort), bob))))
+	solver.Add(Not(Eq(alice, bob)))
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+
+	// Each property must hold, so its negation must be unsatisfiable.
+	for _, property := range []*Expr{
+		SetSubset(admins, readers),
+		SetMember(bob, SetDifference(readers, admins)),
+		Not(SetMember(bob, SetIntersect(admins, readers))),
+		Not(SetMember(alice, SetComplement(readers))),
+		Eq(SetDel(readers, bob), admins),
+		SetSubset(readers, ctx.FullSet(userSort)),
+	} {
+		if property == nil {
+			t.Fatal("Unexpected error:", ctx.LastError)
+		}
+		solver.Push()
+		solver.Add(Not(property))
+		if result, err := solver.Check(); result != LFalse || err != nil {
+			t.Error("Expected", LFalse, "for", property, "got", result, err)
+		}
+		solver.Pop(1)
+	}
+}
+
+func TestSetHasSize(t *testing.T) {
+	ctx := getContext()
+	s := SetAdd(SetAdd(ctx.EmptySet(ctx.IntSort()), ctx.IntVal(1)), ctx.IntVal(2))
+	if e := SetHasSize(s, ctx.IntVal(2)); e == nil && ctx.LastError == nil {
+		t.Error("Expected an error to be recorded for unsupported cardinality")
+	}
+}
@@ -0,0 +1,148 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import "runtime"
+
+// -----------------------------------------------------------------------------
+// Statistics
+
+// Statistics holds performance counters, such as conflicts, decisions and
+// memory use, collected by a solver, optimizer or fixedpoint engine. Each
+// entry has either an unsigned or a floating-point value.
+type Statistics struct {
+	z3val  C.Z3_stats
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newStatistics(z3stats C.Z3_stats) *Statistics {
+	if err := ctx.getError(); err != nil {
+		return nil
+	}
+	stats := &Statistics{z3val: z3stats, ctx: ctx}
+	C.Z3_stats_inc_ref(ctx.z3val, z3stats)
+	ctx.acquire()
+	runtime.SetFinalizer(stats, (*Statistics).finalize)
+	return stats
+}
+
+func (stats *Statistics) String() string {
+	if stats.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_stats_to_string(stats.ctx.z3val, stats.z3val))
+}
+
+// Close releases the statistics. Calling Close more than once is a no-op.
+func (stats *Statistics) Close() error {
+	if !stats.closed {
+		stats.closed = true
+		runtime.SetFinalizer(stats, nil)
+		stats.ctx.releasePending()
+		stats.ctx.release(stats.decRef())
+	}
+	return nil
+}
+
+func (stats *Statistics) finalize() {
+	stats.ctx.queueRelease(stats.decRef())
+}
+
+func (stats *Statistics) decRef() func() {
+	z3ctx, z3val := stats.ctx.z3val, stats.z3val
+	return func() {
+		C.Z3_stats_dec_ref(z3ctx, z3val)
+	}
+}
+
+// size returns the number of entries, or 0 if the statistics are closed.
+func (stats *Statistics) size() int {
+	if stats.closed {
+		return 0
+	}
+	return int(C.Z3_stats_size(stats.ctx.z3val, stats.z3val))
+}
+
+func (stats *Statistics) key(i int) string {
+	return C.GoString(C.Z3_stats_get_key(stats.ctx.z3val, stats.z3val, C.uint(i)))
+}
+
+// Keys returns the keys of the entries, such as "conflicts" or "memory".
+func (stats *Statistics) Keys() []string {
+	keys := make([]string, stats.size())
+	for i := range keys {
+		keys[i] = stats.key(i)
+	}
+	return keys
+}
+
+// find returns the index of the entry with the given key, or -1.
+func (stats *Statistics) find(key string) int {
+	for i, n := 0, stats.size(); i < n; i++ {
+		if stats.key(i) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Uint returns the value of an unsigned entry. It reports false if there is
+// no such entry or its value is a floating-point number.
+func (stats *Statistics) Uint(key string) (uint, bool) {
+	i := stats.find(key)
+	if i < 0 || C.Z3_stats_is_uint(stats.ctx.z3val, stats.z3val, C.uint(i)) != C.Z3_TRUE {
+		return 0, false
+	}
+	return uint(C.Z3_stats_get_uint_value(stats.ctx.z3val, stats.z3val, C.uint(i))), true
+}
+
+// Double returns the value of a floating-point entry, such as "time" or
+// "memory". It reports false if there is no such entry or its value is an
+// unsigned number.
+func (stats *Statistics) Double(key string) (float64, bool) {
+	i := stats.find(key)
+	if i < 0 || C.Z3_stats_is_double(stats.ctx.z3val, stats.z3val, C.uint(i)) != C.Z3_TRUE {
+		return 0, false
+	}
+	return float64(C.Z3_stats_get_double_value(stats.ctx.z3val, stats.z3val, C.uint(i))), true
+}
+
+// Map returns the entries keyed by name, with unsigned values converted to
+// float64.
+func (stats *Statistics) Map() map[string]float64 {
+	n := stats.size()
+	m := make(map[string]float64, n)
+	for i := 0; i < n; i++ {
+		if C.Z3_stats_is_uint(stats.ctx.z3val, stats.z3val, C.uint(i)) == C.Z3_TRUE {
+			m[stats.key(i)] = float64(C.Z3_stats_get_uint_value(stats.ctx.z3val, stats.z3val, C.uint(i)))
+		} else {
+			m[stats.key(i)] = float64(C.Z3_stats_get_double_value(stats.ctx.z3val, stats.z3val, C.uint(i)))
+		}
+	}
+	return m
+}
+
+// Statistics returns the statistics collected by the solver so far.
+func (solver *Solver) Statistics() *Statistics {
+	if err := solver.checkOpen(); err != nil {
+		return nil
+	}
+	return solver.ctx.newStatistics(C.Z3_solver_get_statistics(solver.ctx.z3val, solver.z3val))
+}
+
+// Statistics returns the statistics collected by the optimizer so far.
+func (opt *Optimizer) Statistics() *Statistics {
+	if err := opt.checkOpen(); err != nil {
+		return nil
+	}
+	return opt.ctx.newStatistics(C.Z3_optimize_get_statistics(opt.ctx.z3val, opt.z3val))
+}
+
+// Statistics returns the statistics collected by the engine so far.
+func (fp *Fixedpoint) Statistics() *Statistics {
+	if err := fp.checkOpen(); err != nil {
+		return nil
+	}
+	return fp.ctx.newStatistics(C.Z3_fixedpoint_get_statistics(fp.ctx.z3val, fp.z3val))
+}
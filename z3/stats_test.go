@@ -0,0 +1,63 @@
+package z3
+
+import "testing"
+
+func TestSolverStatistics(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	solver.Add(Or(Gt(x, y), Gt(x, ctx.IntVal(5))), Gt(y, ctx.IntVal(3)), Lt(x, ctx.IntVal(2)))
+	if result, err := solver.Check(); result != LFalse || err != nil {
+		t.Fatal("Expected", LFalse, "got", result, err)
+	}
+	stats := solver.Statistics()
+	if stats == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	defer stats.Close()
+
+	keys := stats.Keys()
+	if len(keys) == 0 {
+		t.Fatal("Expected statistics entries, got", stats)
+	}
+	m := stats.Map()
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			t.Error("Expected", key, "in map")
+		}
+	}
+	memory, ok := stats.Double("memory")
+	if !ok || memory <= 0 || memory != m["memory"] {
+		t.Error("Expected positive memory, got", memory, ok)
+	}
+	if _, ok := stats.Uint("memory"); ok {
+		t.Error("Expected memory not to be an unsigned entry")
+	}
+	for _, key := range keys {
+		if n, ok := stats.Uint(key); ok && float64(n) != m[key] {
+			t.Error("Expected", m[key], "for", key, "got", n)
+		}
+	}
+	if _, ok := stats.Uint("no such key"); ok {
+		t.Error("Expected missing key to be reported")
+	}
+}
+
+func TestOptimizerStatistics(t *testing.T) {
+	ctx := getContext()
+	opt := NewOptimizer(ctx)
+	x := ctx.IntConst("x")
+	opt.Add(Lt(x, ctx.IntVal(10)))
+	opt.Maximize(x)
+	opt.Check()
+	if stats := opt.Statistics(); stats == nil || len(stats.Keys()) == 0 {
+		t.Error("Expected optimizer statistics, got", stats, ctx.LastError)
+	}
+}
+
+func TestStatisticsFinalizer(t *testing.T) {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	NewSolver(ctx).Statistics().Keys()
+	waitForDeletion(t, deleted)
+}
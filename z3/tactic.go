@@ -0,0 +1,820 @@
+package z3
+
+// #include <stdlib.h>
+// #include <z3.h>
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Goals
+
+// GoalPrecision describes the approximations applied to a goal.
+type GoalPrecision int
+
+const (
+	// GoalPrecise goals preserve both sat and unsat answers.
+	GoalPrecise GoalPrecision = C.Z3_GOAL_PRECISE
+	// GoalUnder goals are under-approximations, preserving sat answers.
+	GoalUnder GoalPrecision = C.Z3_GOAL_UNDER
+	// GoalOver goals are over-approximations, preserving unsat answers.
+	GoalOver GoalPrecision = C.Z3_GOAL_OVER
+	// GoalUnderOver goals preserve neither answer.
+	GoalUnderOver GoalPrecision = C.Z3_GOAL_UNDER_OVER
+)
+
+func (prec GoalPrecision) String() string {
+	switch prec {
+	case GoalPrecise:
+		return "precise"
+	case GoalUnder:
+		return "under"
+	case GoalOver:
+		return "over"
+	case GoalUnderOver:
+		return "under-over"
+	default:
+		return ""
+	}
+}
+
+// Goal is a set of formulas that tactics transform into subgoals.
+type Goal struct {
+	z3val  C.Z3_goal
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newGoal(z3goal C.Z3_goal) *Goal {
+	goal := &Goal{z3val: z3goal, ctx: ctx}
+	C.Z3_goal_inc_ref(ctx.z3val, z3goal)
+	ctx.acquire()
+	runtime.SetFinalizer(goal, (*Goal).finalize)
+	return goal
+}
+
+// NewGoal creates an empty goal. The flags enable model generation, unsat core
+// extraction and proof generation for tactics applied to the goal; proofs also
+// require the context to be created with proof generation enabled. It returns
+// nil if the context is closed.
+func NewGoal(ctx *Context, models, unsatCores, proofs bool) *Goal {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3goal, err := C.Z3_mk_goal(ctx.z3val, getZ3Bool(models), getZ3Bool(unsatCores),
+		getZ3Bool(proofs)), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newGoal(z3goal)
+}
+
+func (goal *Goal) String() string {
+	if goal.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_goal_to_string(goal.ctx.z3val, goal.z3val))
+}
+
+// Close releases the goal. Calling Close more than once is a no-op.
+func (goal *Goal) Close() error {
+	if !goal.closed {
+		goal.closed = true
+		runtime.SetFinalizer(goal, nil)
+		goal.ctx.releasePending()
+		goal.ctx.release(goal.decRef())
+	}
+	return nil
+}
+
+func (goal *Goal) finalize() {
+	goal.ctx.queueRelease(goal.decRef())
+}
+
+func (goal *Goal) decRef() func() {
+	z3ctx, z3val := goal.ctx.z3val, goal.z3val
+	return func() {
+		C.Z3_goal_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (goal *Goal) checkOpen() error {
+	if goal.closed {
+		return goal.ctx.usageError("goal is closed")
+	}
+	return nil
+}
+
+// Add adds formulas to the goal.
+func (goal *Goal) Add(a ...*Expr) error {
+	if err := goal.checkOpen(); err != nil {
+		return err
+	}
+	for _, expr := range a {
+		if err := goal.ctx.checkOperands(expr); err != nil {
+			return err
+		}
+		C.Z3_goal_assert(goal.ctx.z3val, goal.z3val, expr.z3val)
+		if err := goal.ctx.getError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset removes all formulas from the goal.
+func (goal *Goal) Reset() error {
+	if err := goal.checkOpen(); err != nil {
+		return err
+	}
+	C.Z3_goal_reset(goal.ctx.z3val, goal.z3val)
+	return goal.ctx.getError()
+}
+
+// Precision returns the approximations applied to the goal by tactics.
+func (goal *Goal) Precision() GoalPrecision {
+	if goal.closed {
+		return GoalUnderOver
+	}
+	return GoalPrecision(C.Z3_goal_precision(goal.ctx.z3val, goal.z3val))
+}
+
+// Depth returns the number of tactic transformations that produced the goal.
+func (goal *Goal) Depth() uint {
+	if goal.closed {
+		return 0
+	}
+	return uint(C.Z3_goal_depth(goal.ctx.z3val, goal.z3val))
+}
+
+// Inconsistent checks whether the goal contains the formula false.
+func (goal *Goal) Inconsistent() bool {
+	if goal.closed {
+		return false
+	}
+	return C.Z3_goal_inconsistent(goal.ctx.z3val, goal.z3val) == C.Z3_TRUE
+}
+
+// IsDecidedSat checks whether the goal is empty, and therefore satisfiable.
+func (goal *Goal) IsDecidedSat() bool {
+	if goal.closed {
+		return false
+	}
+	return C.Z3_goal_is_decided_sat(goal.ctx.z3val, goal.z3val) == C.Z3_TRUE
+}
+
+// IsDecidedUnsat checks whether the goal is inconsistent, and therefore
+// unsatisfiable.
+func (goal *Goal) IsDecidedUnsat() bool {
+	if goal.closed {
+		return false
+	}
+	return C.Z3_goal_is_decided_unsat(goal.ctx.z3val, goal.z3val) == C.Z3_TRUE
+}
+
+// Size returns the number of formulas in the goal.
+func (goal *Goal) Size() uint {
+	if goal.closed {
+		return 0
+	}
+	return uint(C.Z3_goal_size(goal.ctx.z3val, goal.z3val))
+}
+
+// NumExprs returns the number of subexpressions of the formulas in the goal.
+func (goal *Goal) NumExprs() uint {
+	if goal.closed {
+		return 0
+	}
+	return uint(C.Z3_goal_num_exprs(goal.ctx.z3val, goal.z3val))
+}
+
+// Formula returns the i-th formula of the goal.
+func (goal *Goal) Formula(i uint) *Expr {
+	if err := goal.checkOpen(); err != nil {
+		return nil
+	}
+	if n := goal.Size(); i >= n {
+		goal.ctx.setError(&Error{IOB, fmt.Sprintf("formula index %d out of bounds for goal of size %d", i, n)})
+		return nil
+	}
+	z3ast, err := C.Z3_goal_formula(goal.ctx.z3val, goal.z3val, C.uint(i)), goal.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return goal.ctx.newExpr(z3ast)
+}
+
+// Formulas returns the formulas of the goal.
+func (goal *Goal) Formulas() []*Expr {
+	if err := goal.checkOpen(); err != nil {
+		return nil
+	}
+	formulas := make([]*Expr, goal.Size())
+	for i := range formulas {
+		if formulas[i] = goal.Formula(uint(i)); formulas[i] == nil {
+			return nil
+		}
+	}
+	return formulas
+}
+
+// AsExpr returns the conjunction of the formulas of the goal.
+func (goal *Goal) AsExpr() *Expr {
+	formulas := goal.Formulas()
+	if formulas == nil {
+		return nil
+	}
+	return goal.ctx.And(formulas...)
+}
+
+// ConvertModel converts a model of the goal into a model of the goal it was
+// derived from by a tactic.
+func (goal *Goal) ConvertModel(m *Model) *Model {
+	if err := goal.checkOpen(); err != nil {
+		return nil
+	}
+	if m == nil || m.ctx != goal.ctx {
+		goal.ctx.setError(&Error{InvalidArg, "model is nil or belongs to a different context"})
+		return nil
+	}
+	if err := m.checkOpen(); err != nil {
+		return nil
+	}
+	z3model, err := C.Z3_goal_convert_model(goal.ctx.z3val, goal.z3val, m.z3val), goal.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return goal.ctx.newModel(z3model)
+}
+
+// -----------------------------------------------------------------------------
+// Tactics
+
+// Tactic transforms a goal into a set of subgoals.
+type Tactic struct {
+	z3val  C.Z3_tactic
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newTactic(z3tactic C.Z3_tactic) *Tactic {
+	if err := ctx.getError(); err != nil {
+		return nil
+	}
+	tactic := &Tactic{z3val: z3tactic, ctx: ctx}
+	C.Z3_tactic_inc_ref(ctx.z3val, z3tactic)
+	ctx.acquire()
+	runtime.SetFinalizer(tactic, (*Tactic).finalize)
+	return tactic
+}
+
+// NewTactic returns the built-in tactic with the given name, such as
+// "simplify" or "solve-eqs". It returns nil if the context is closed or there
+// is no such tactic.
+func NewTactic(ctx *Context, name string) *Tactic {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return ctx.newTactic(C.Z3_mk_tactic(ctx.z3val, cName))
+}
+
+// TacticNames returns the names of the built-in tactics.
+func (ctx *Context) TacticNames() []string {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	names := make([]string, C.Z3_get_num_tactics(ctx.z3val))
+	for i := range names {
+		names[i] = C.GoString(C.Z3_get_tactic_name(ctx.z3val, C.uint(i)))
+	}
+	return names
+}
+
+// Close releases the tactic. Calling Close more than once is a no-op.
+func (tactic *Tactic) Close() error {
+	if !tactic.closed {
+		tactic.closed = true
+		runtime.SetFinalizer(tactic, nil)
+		tactic.ctx.releasePending()
+		tactic.ctx.release(tactic.decRef())
+	}
+	return nil
+}
+
+func (tactic *Tactic) finalize() {
+	tactic.ctx.queueRelease(tactic.decRef())
+}
+
+func (tactic *Tactic) decRef() func() {
+	z3ctx, z3val := tactic.ctx.z3val, tactic.z3val
+	return func() {
+		C.Z3_tactic_dec_ref(z3ctx, z3val)
+	}
+}
+
+// tacticContext returns the context of the given tactics, or nil after
+// recording an error if they are nil, closed or from different contexts.
+func tacticContext(tactics ...*Tactic) *Context {
+	for _, tactic := range tactics {
+		if tactic != nil {
+			if err := tactic.ctx.checkOpen(); err != nil {
+				return nil
+			}
+			if err := tactic.ctx.checkTactics(tactics...); err != nil {
+				return nil
+			}
+			return tactic.ctx
+		}
+	}
+	return nil
+}
+
+// checkTactics records and returns an error if any of the given tactics is
+// nil, closed or belongs to a different context.
+func (ctx *Context) checkTactics(tactics ...*Tactic) error {
+	for i, tactic := range tactics {
+		if tactic == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil tactic operand at position %d", i)})
+		}
+		if tactic.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("tactic operand at position %d belongs to a different context", i)})
+		}
+		if tactic.closed {
+			return ctx.usageError(fmt.Sprintf("tactic operand at position %d is closed", i))
+		}
+	}
+	return nil
+}
+
+// Help returns a description of the tactic and its parameters.
+func (tactic *Tactic) Help() string {
+	if tactic.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_tactic_get_help(tactic.ctx.z3val, tactic.z3val))
+}
+
+// ParamDescrs describes the parameters accepted by the tactic.
+func (tactic *Tactic) ParamDescrs() *ParamDescrs {
+	ctx := tacticContext(tactic)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newParamDescrs(C.Z3_tactic_get_param_descrs(ctx.z3val, tactic.z3val))
+}
+
+// Apply applies the tactic to the goal.
+func (tactic *Tactic) Apply(goal *Goal) *ApplyResult {
+	ctx := tacticContext(tactic)
+	if ctx == nil {
+		return nil
+	}
+	if goal == nil || goal.ctx != ctx {
+		ctx.setError(&Error{InvalidArg, "goal is nil or belongs to a different context"})
+		return nil
+	}
+	if err := goal.checkOpen(); err != nil {
+		return nil
+	}
+	z3result, err := C.Z3_tactic_apply(ctx.z3val, tactic.z3val, goal.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newApplyResult(z3result)
+}
+
+// AndThen returns the tactic that applies each of one or more tactics in turn
+// to the subgoals produced by the previous one.
+func AndThen(t ...*Tactic) *Tactic {
+	ctx := tacticContext(t...)
+	if ctx == nil {
+		return nil
+	}
+	result := t[0]
+	for _, next := range t[1:] {
+		if result = ctx.newTactic(C.Z3_tactic_and_then(ctx.z3val, result.z3val, next.z3val)); result == nil {
+			return nil
+		}
+	}
+	return result
+}
+
+// OrElse returns the tactic that applies the first of one or more tactics that
+// does not fail.
+func OrElse(t ...*Tactic) *Tactic {
+	ctx := tacticContext(t...)
+	if ctx == nil {
+		return nil
+	}
+	result := t[len(t)-1]
+	for i := len(t) - 2; i >= 0; i-- {
+		if result = ctx.newTactic(C.Z3_tactic_or_else(ctx.z3val, t[i].z3val, result.z3val)); result == nil {
+			return nil
+		}
+	}
+	return result
+}
+
+// ParOr returns the tactic that applies one or more tactics in parallel and
+// uses the first one to succeed.
+func ParOr(t ...*Tactic) *Tactic {
+	ctx := tacticContext(t...)
+	if ctx == nil {
+		return nil
+	}
+	tactics := make([]C.Z3_tactic, len(t))
+	for i, tactic := range t {
+		tactics[i] = tactic.z3val
+	}
+	return ctx.newTactic(C.Z3_tactic_par_or(ctx.z3val, C.uint(len(tactics)), &tactics[0]))
+}
+
+// ParAndThen returns the tactic that applies t1 and then t2 to each of the
+// resulting subgoals in parallel.
+func ParAndThen(t1, t2 *Tactic) *Tactic {
+	ctx := tacticContext(t1, t2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_par_and_then(ctx.z3val, t1.z3val, t2.z3val))
+}
+
+// Repeat returns the tactic that applies t to a goal and its subgoals until
+// the goal is unchanged or max iterations have been performed.
+func Repeat(t *Tactic, max uint) *Tactic {
+	ctx := tacticContext(t)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_repeat(ctx.z3val, t.z3val, C.uint(max)))
+}
+
+// TryFor returns the tactic that applies t for at most ms milliseconds and
+// fails if it does not terminate in time.
+func TryFor(t *Tactic, ms uint) *Tactic {
+	ctx := tacticContext(t)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_try_for(ctx.z3val, t.z3val, C.uint(ms)))
+}
+
+// When returns the tactic that applies t if the probe p evaluates to true, and
+// leaves the goal unchanged otherwise.
+func When(p *Probe, t *Tactic) *Tactic {
+	ctx := tacticContext(t)
+	if ctx == nil || ctx.checkProbes(p) != nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_when(ctx.z3val, p.z3val, t.z3val))
+}
+
+// Cond returns the tactic that applies t1 if the probe p evaluates to true and
+// t2 otherwise.
+func Cond(p *Probe, t1, t2 *Tactic) *Tactic {
+	ctx := tacticContext(t1, t2)
+	if ctx == nil || ctx.checkProbes(p) != nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_cond(ctx.z3val, p.z3val, t1.z3val, t2.z3val))
+}
+
+// FailIf returns the tactic that fails if the probe p evaluates to true, and
+// leaves the goal unchanged otherwise.
+func FailIf(p *Probe) *Tactic {
+	ctx := probeContext(p)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_fail_if(ctx.z3val, p.z3val))
+}
+
+// UsingParams returns the tactic that applies t with the given parameters.
+// Parameters are checked by Params.Validate against the ParamDescrs of t.
+func UsingParams(t *Tactic, params *Params) *Tactic {
+	ctx := tacticContext(t)
+	if ctx == nil || ctx.checkParams(params) != nil {
+		return nil
+	}
+	descrs := t.ParamDescrs()
+	if descrs == nil {
+		return nil
+	}
+	defer descrs.Close()
+	if err := params.Validate(descrs); err != nil {
+		return nil
+	}
+	return ctx.newTactic(C.Z3_tactic_using_params(ctx.z3val, t.z3val, params.z3val))
+}
+
+// -----------------------------------------------------------------------------
+// Apply results
+
+// ApplyResult holds the subgoals produced by applying a tactic to a goal.
+type ApplyResult struct {
+	z3val  C.Z3_apply_result
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newApplyResult(z3result C.Z3_apply_result) *ApplyResult {
+	result := &ApplyResult{z3val: z3result, ctx: ctx}
+	C.Z3_apply_result_inc_ref(ctx.z3val, z3result)
+	ctx.acquire()
+	runtime.SetFinalizer(result, (*ApplyResult).finalize)
+	return result
+}
+
+func (result *ApplyResult) String() string {
+	if result.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_apply_result_to_string(result.ctx.z3val, result.z3val))
+}
+
+// Close releases the result. Subgoals obtained from it remain valid. Calling
+// Close more than once is a no-op.
+func (result *ApplyResult) Close() error {
+	if !result.closed {
+		result.closed = true
+		runtime.SetFinalizer(result, nil)
+		result.ctx.releasePending()
+		result.ctx.release(result.decRef())
+	}
+	return nil
+}
+
+func (result *ApplyResult) finalize() {
+	result.ctx.queueRelease(result.decRef())
+}
+
+func (result *ApplyResult) decRef() func() {
+	z3ctx, z3val := result.ctx.z3val, result.z3val
+	return func() {
+		C.Z3_apply_result_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (result *ApplyResult) checkOpen() error {
+	if result.closed {
+		return result.ctx.usageError("apply result is closed")
+	}
+	return nil
+}
+
+// NumSubgoals returns the number of subgoals.
+func (result *ApplyResult) NumSubgoals() uint {
+	if result.closed {
+		return 0
+	}
+	return uint(C.Z3_apply_result_get_num_subgoals(result.ctx.z3val, result.z3val))
+}
+
+// Subgoal returns the i-th subgoal.
+func (result *ApplyResult) Subgoal(i uint) *Goal {
+	if err := result.checkOpen(); err != nil {
+		return nil
+	}
+	if n := result.NumSubgoals(); i >= n {
+		result.ctx.setError(&Error{IOB, fmt.Sprintf("subgoal index %d out of bounds for %d subgoals", i, n)})
+		return nil
+	}
+	z3goal, err := C.Z3_apply_result_get_subgoal(result.ctx.z3val, result.z3val, C.uint(i)), result.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return result.ctx.newGoal(z3goal)
+}
+
+// Subgoals returns all subgoals.
+func (result *ApplyResult) Subgoals() []*Goal {
+	if err := result.checkOpen(); err != nil {
+		return nil
+	}
+	goals := make([]*Goal, result.NumSubgoals())
+	for i := range goals {
+		if goals[i] = result.Subgoal(uint(i)); goals[i] == nil {
+			return nil
+		}
+	}
+	return goals
+}
+
+// ConvertModel converts a model of the i-th subgoal into a model of the goal
+// the tactic was applied to.
+func (result *ApplyResult) ConvertModel(i uint, m *Model) *Model {
+	goal := result.Subgoal(i)
+	if goal == nil {
+		return nil
+	}
+	defer goal.Close()
+	return goal.ConvertModel(m)
+}
+
+// -----------------------------------------------------------------------------
+// Probes
+
+// Probe evaluates a numeric measure of a goal, such as its size, used to
+// select tactics. Boolean probes evaluate to 1 for true and 0 for false.
+type Probe struct {
+	z3val  C.Z3_probe
+	ctx    *Context
+	closed bool
+}
+
+func (ctx *Context) newProbe(z3probe C.Z3_probe) *Probe {
+	if err := ctx.getError(); err != nil {
+		return nil
+	}
+	probe := &Probe{z3val: z3probe, ctx: ctx}
+	C.Z3_probe_inc_ref(ctx.z3val, z3probe)
+	ctx.acquire()
+	runtime.SetFinalizer(probe, (*Probe).finalize)
+	return probe
+}
+
+// NewProbe returns the built-in probe with the given name, such as
+// "num-consts" or "is-qfbv". It returns nil if the context is closed or there
+// is no such probe.
+func NewProbe(ctx *Context, name string) *Probe {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return ctx.newProbe(C.Z3_mk_probe(ctx.z3val, cName))
+}
+
+// NewConstProbe returns the probe that always evaluates to value.
+func NewConstProbe(ctx *Context, value float64) *Probe {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_const(ctx.z3val, C.double(value)))
+}
+
+// ProbeNames returns the names of the built-in probes.
+func (ctx *Context) ProbeNames() []string {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	names := make([]string, C.Z3_get_num_probes(ctx.z3val))
+	for i := range names {
+		names[i] = C.GoString(C.Z3_get_probe_name(ctx.z3val, C.uint(i)))
+	}
+	return names
+}
+
+// Close releases the probe. Calling Close more than once is a no-op.
+func (probe *Probe) Close() error {
+	if !probe.closed {
+		probe.closed = true
+		runtime.SetFinalizer(probe, nil)
+		probe.ctx.releasePending()
+		probe.ctx.release(probe.decRef())
+	}
+	return nil
+}
+
+func (probe *Probe) finalize() {
+	probe.ctx.queueRelease(probe.decRef())
+}
+
+func (probe *Probe) decRef() func() {
+	z3ctx, z3val := probe.ctx.z3val, probe.z3val
+	return func() {
+		C.Z3_probe_dec_ref(z3ctx, z3val)
+	}
+}
+
+// probeContext returns the context of the given probes, or nil after
+// recording an error if they are nil, closed or from different contexts.
+func probeContext(probes ...*Probe) *Context {
+	for _, probe := range probes {
+		if probe != nil {
+			if err := probe.ctx.checkOpen(); err != nil {
+				return nil
+			}
+			if err := probe.ctx.checkProbes(probes...); err != nil {
+				return nil
+			}
+			return probe.ctx
+		}
+	}
+	return nil
+}
+
+// checkProbes records and returns an error if any of the given probes is nil,
+// closed or belongs to a different context.
+func (ctx *Context) checkProbes(probes ...*Probe) error {
+	for i, probe := range probes {
+		if probe == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil probe operand at position %d", i)})
+		}
+		if probe.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("probe operand at position %d belongs to a different context", i)})
+		}
+		if probe.closed {
+			return ctx.usageError(fmt.Sprintf("probe operand at position %d is closed", i))
+		}
+	}
+	return nil
+}
+
+// Apply evaluates the probe on the goal.
+func (probe *Probe) Apply(goal *Goal) (float64, error) {
+	ctx := probe.ctx
+	if err := ctx.checkProbes(probe); err != nil {
+		return 0, err
+	}
+	if goal == nil || goal.ctx != ctx {
+		return 0, ctx.setError(&Error{InvalidArg, "goal is nil or belongs to a different context"})
+	}
+	if err := goal.checkOpen(); err != nil {
+		return 0, err
+	}
+	value, err := C.Z3_probe_apply(ctx.z3val, probe.z3val, goal.z3val), ctx.getError()
+	return float64(value), err
+}
+
+// ProbeLt returns the probe that checks whether p1 evaluates to less than p2.
+func ProbeLt(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_lt(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeLe returns the probe that checks whether p1 evaluates to at most p2.
+func ProbeLe(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_le(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeGt returns the probe that checks whether p1 evaluates to more than p2.
+func ProbeGt(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_gt(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeGe returns the probe that checks whether p1 evaluates to at least p2.
+func ProbeGe(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_ge(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeEq returns the probe that checks whether p1 and p2 evaluate to the same
+// value.
+func ProbeEq(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_eq(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeAnd returns the conjunction of the Boolean probes p1 and p2.
+func ProbeAnd(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_and(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeOr returns the disjunction of the Boolean probes p1 and p2.
+func ProbeOr(p1, p2 *Probe) *Probe {
+	ctx := probeContext(p1, p2)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_or(ctx.z3val, p1.z3val, p2.z3val))
+}
+
+// ProbeNot returns the negation of the Boolean probe p.
+func ProbeNot(p *Probe) *Probe {
+	ctx := probeContext(p)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.newProbe(C.Z3_probe_not(ctx.z3val, p.z3val))
+}
@@ -0,0 +1,128 @@
+package z3
+
+import "testing"
+
+func TestTacticApply(t *testing.T) {
+	ctx := getContext()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	goal := NewGoal(ctx, true, false, false)
+	goal.Add(Eq(x, Add(y, ctx.IntVal(1))), Gt(y, ctx.IntVal(2)), Or(Lt(x, ctx.IntVal(0)), Gt(x, ctx.IntVal(5))))
+	if size := goal.Size(); size != 3 {
+		t.Fatal("Expected 3 formulas, got", size)
+	}
+	if prec := goal.Precision(); prec != GoalPrecise {
+		t.Error("Expected", GoalPrecise, "got", prec)
+	}
+
+	tactic := AndThen(NewTactic(ctx, "simplify"), NewTactic(ctx, "solve-eqs"), NewTactic(ctx, "split-clause"))
+	if tactic == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	result := tactic.Apply(goal)
+	if result == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	if n := result.NumSubgoals(); n != 2 {
+		t.Fatal("Expected 2 subgoals, got", n, result)
+	}
+	for i, subgoal := range result.Subgoals() {
+		if subgoal.Depth() == 0 {
+			t.Error("Expected nonzero depth for subgoal", i)
+		}
+	}
+
+	// Solve the second subgoal and convert its model back to the original goal.
+	subgoal := result.Subgoal(1)
+	solver := NewSolver(ctx)
+	solver.Add(subgoal.AsExpr())
+	if r, err := solver.Check(); r != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", r, err)
+	}
+	model := result.ConvertModel(1, solver.GetModel())
+	if model == nil {
+		t.Fatal("Unexpected error:", ctx.LastError)
+	}
+	check := NewSolver(ctx)
+	check.Add(goal.AsExpr(), Eq(x, model.Eval(x, true)), Eq(y, model.Eval(y, true)))
+	if r, err := check.Check(); r != LTrue || err != nil {
+		t.Error("Expected converted model to satisfy the goal, got", r, err)
+	}
+
+	if NewTactic(ctx, "no-such-tactic") != nil {
+		t.Error("Expected nil for unknown tactic")
+	}
+	if result.Subgoal(5) != nil {
+		t.Error("Expected nil for out of bounds subgoal")
+	}
+	expectErrorCode(t, ctx.LastError, IOB)
+}
+
+func TestTacticCombinators(t *testing.T) {
+	ctx := getContext()
+	x := ctx.IntConst("x")
+	goal := NewGoal(ctx, false, false, false)
+	goal.Add(Gt(x, ctx.IntVal(0)), Lt(x, ctx.IntVal(0)))
+
+	numConsts := NewProbe(ctx, "num-consts")
+	if value, err := numConsts.Apply(goal); value != 1 || err != nil {
+		t.Error("Expected 1 constant, got", value, err)
+	}
+	small := ProbeLt(numConsts, NewConstProbe(ctx, 10))
+	if value, err := ProbeAnd(small, ProbeNot(ProbeEq(numConsts, NewConstProbe(ctx, 0)))).Apply(goal); value != 1 || err != nil {
+		t.Error("Expected probe to hold, got", value, err)
+	}
+
+	params := NewParams(ctx)
+	params.SetBool("arith_lhs", true)
+	smt := NewTactic(ctx, "smt")
+	for _, tactic := range []*Tactic{
+		OrElse(NewTactic(ctx, "fail"), smt),
+		Cond(small, smt, NewTactic(ctx, "fail")),
+		When(small, Repeat(TryFor(smt, 10000), 3)),
+		ParOr(NewTactic(ctx, "fail"), smt),
+		ParAndThen(UsingParams(NewTactic(ctx, "simplify"), params), smt),
+	} {
+		if tactic == nil {
+			t.Fatal("Unexpected error:", ctx.LastError)
+		}
+		result := tactic.Apply(goal)
+		if result == nil || result.NumSubgoals() != 1 || !result.Subgoal(0).IsDecidedUnsat() {
+			t.Error("Expected an unsat subgoal, got", result, ctx.LastError)
+		}
+	}
+	if result := FailIf(small).Apply(goal); result != nil {
+		t.Error("Expected FailIf to fail, got", result)
+	}
+}
+
+func TestSolverFromTactic(t *testing.T) {
+	ctx := getContext()
+	tactic := AndThen(NewTactic(ctx, "simplify"), NewTactic(ctx, "bit-blast"), NewTactic(ctx, "sat"))
+	solver := NewSolverFromTactic(ctx, tactic)
+	x := ctx.BVConst("x", 8)
+	solver.Add(Eq(BVMul(x, ctx.BVUintVal(3, 8)), ctx.BVUintVal(21, 8)))
+	if r, err := solver.Check(); r != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", r, err)
+	}
+	if value := solver.GetModel().Eval(x, true).String(); value != "#x07" {
+		t.Error("Expected #x07, got", value)
+	}
+
+	tactic.Close()
+	if NewSolverFromTactic(ctx, tactic) != nil {
+		t.Error("Expected nil solver for closed tactic")
+	}
+	expectUsageError(t, ctx.LastError)
+}
+
+func TestTacticFinalizer(t *testing.T) {
+	ctx := getContext()
+	deleted := watchDeletion(ctx)
+	goal := NewGoal(ctx, false, false, false)
+	goal.Add(ctx.BoolConst("p"))
+	params := NewParams(ctx)
+	params.SetBool("elim_and", true)
+	UsingParams(NewTactic(ctx, "simplify"), params).Apply(goal).Subgoal(0)
+	NewProbe(ctx, "size").Apply(goal)
+	waitForDeletion(t, deleted)
+}
@@ -0,0 +1,55 @@
+package z3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReasonUnknown(t *testing.T) {
+	ctx := getContext()
+	solver := NewSolver(ctx)
+	params := NewParams(ctx)
+	params.SetUint("timeout", 50)
+	solver.SetParams(params)
+	addPigeonhole(ctx, solver, 14)
+	result, err := solver.Check()
+	if result != LUndef || !errors.Is(err, ErrTimeout) {
+		t.Fatal("Expected", LUndef, ErrTimeout, "got", result, err)
+	}
+	if reason := solver.ReasonUnknown(); err.(*UnknownError).Reason != reason {
+		t.Error("Expected reason", reason, "got", err)
+	}
+	if errors.Is(err, ErrIncomplete) || errors.Is(err, ErrCanceled) {
+		t.Error("Expected timeout only, got", err)
+	}
+
+	solver = NewSolver(ctx)
+	params = NewParams(ctx)
+	params.SetUint("rlimit", 1000)
+	solver.SetParams(params)
+	addPigeonhole(ctx, solver, 14)
+	if result, err := solver.Check(); result != LUndef || !errors.Is(err, ErrResourceLimit) {
+		t.Error("Expected", LUndef, ErrResourceLimit, "got", result, err)
+	}
+}
+
+func TestUnknownClassification(t *testing.T) {
+	for _, c := range []struct {
+		reason   string
+		sentinel error
+	}{
+		{"timeout", ErrTimeout},
+		{"canceled", ErrCanceled},
+		{"max. resource limit exceeded", ErrResourceLimit},
+		{"max. memory exceeded", ErrResourceLimit},
+		{"incomplete quantifiers", ErrIncomplete},
+		{"(incomplete (theory arithmetic))", ErrIncomplete},
+	} {
+		err := error(&UnknownError{Reason: c.reason})
+		for _, sentinel := range []error{ErrTimeout, ErrCanceled, ErrResourceLimit, ErrIncomplete} {
+			if errors.Is(err, sentinel) != (sentinel == c.sentinel) {
+				t.Errorf("Unexpected classification of %q as %v", c.reason, sentinel)
+			}
+		}
+	}
+}
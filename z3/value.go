@@ -0,0 +1,145 @@
+package z3
+
+// #include <z3.h>
+import "C"
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// -----------------------------------------------------------------------------
+// Values
+//
+// The accessors below convert literals, such as those obtained from
+// Model.Eval with model completion, into Go values. String literals are
+// converted by StringValue, since String prints the expression.
+
+// checkNumeral records and returns an InvalidArg error unless the expression
+// is a numeral of one of the given sort kinds.
+func (expr *Expr) checkNumeral(kinds ...SortKind) error {
+	if expr.IsNumeral() {
+		kind := expr.Sort().SortKind()
+		for _, k := range kinds {
+			if kind == k {
+				return nil
+			}
+		}
+	}
+	return expr.ctx.setError(&Error{InvalidArg,
+		fmt.Sprintf("%s is not a numeral of sort %v", expr, kinds)})
+}
+
+// BigInt returns the value of an integer numeral, or the unsigned value of a
+// bit-vector numeral.
+func (expr *Expr) BigInt() (*big.Int, error) {
+	if err := expr.checkNumeral(IntSort, BVSort); err != nil {
+		return nil, err
+	}
+	numeral := C.GoString(C.Z3_get_numeral_string(expr.ctx.z3val, expr.z3val))
+	if err := expr.ctx.getError(); err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(numeral, 10)
+	if !ok {
+		return nil, expr.ctx.setError(&Error{InvalidArg,
+			fmt.Sprintf("cannot parse numeral %q", numeral)})
+	}
+	return n, nil
+}
+
+// Int64 returns the value of an integer numeral, or the unsigned value of a
+// bit-vector numeral. It fails if the value does not fit in an int64.
+func (expr *Expr) Int64() (int64, error) {
+	n, err := expr.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() {
+		return 0, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s overflows int64", n)})
+	}
+	return n.Int64(), nil
+}
+
+// Uint64 returns the value of a non-negative integer numeral, or the unsigned
+// value of a bit-vector numeral. It fails if the value does not fit in a
+// uint64.
+func (expr *Expr) Uint64() (uint64, error) {
+	n, err := expr.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s overflows uint64", n)})
+	}
+	return n.Uint64(), nil
+}
+
+// Bool returns the value of the Boolean literals true and false.
+func (expr *Expr) Bool() (bool, error) {
+	value, err := C.Z3_get_bool_value(expr.ctx.z3val, expr.z3val), expr.ctx.getError()
+	if err != nil {
+		return false, err
+	}
+	switch value {
+	case C.Z3_L_TRUE:
+		return true, nil
+	case C.Z3_L_FALSE:
+		return false, nil
+	}
+	return false, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a Boolean literal", expr)})
+}
+
+// Float64 returns the value of an integer, real or floating-point numeral,
+// rounded to the nearest float64. It fails if the value is finite but too
+// large for a float64.
+func (expr *Expr) Float64() (float64, error) {
+	if err := expr.checkNumeral(IntSort, RealSort, FloatingPointSort); err != nil {
+		return 0, err
+	}
+	if expr.Sort().SortKind() == FloatingPointSort {
+		return expr.fpFloat64()
+	}
+	r, err := expr.Rat()
+	if err != nil {
+		return 0, err
+	}
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		return 0, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s overflows float64", r.RatString())})
+	}
+	return f, nil
+}
+
+// fpFloat64 returns the value of a floating-point numeral. Z3 only converts
+// double-precision numerals to float64, so numerals of other sorts are rounded
+// to double precision first.
+func (expr *Expr) fpFloat64() (float64, error) {
+	ctx, value := expr.ctx, expr
+	if sort := expr.Sort(); sort.FPEBits() != 11 || sort.FPSBits() != 53 {
+		if value = roundFP(expr, ctx.Float64Sort()); value == nil {
+			return 0, ctx.LastError
+		}
+	}
+	f, err := float64(C.Z3_get_numeral_double(ctx.z3val, value.z3val)), ctx.getError()
+	if err != nil {
+		return 0, err
+	}
+	if math.IsInf(f, 0) && C.Z3_fpa_is_numeral_inf(ctx.z3val, expr.z3val) != C.Z3_TRUE {
+		return 0, ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s overflows float64", expr)})
+	}
+	return f, nil
+}
+
+// Bytes returns the value of a bit-vector numeral in big-endian order, using
+// as many bytes as are needed for its size.
+func (expr *Expr) Bytes() ([]byte, error) {
+	n, err := expr.BigInt()
+	if err != nil {
+		return nil, err
+	}
+	if expr.Sort().SortKind() != BVSort {
+		return nil, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a bit-vector numeral", expr)})
+	}
+	return n.FillBytes(make([]byte, (expr.Sort().BVSize()+7)/8)), nil
+}
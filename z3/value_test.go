@@ -0,0 +1,159 @@
+package z3
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestIntegerValues(t *testing.T) {
+	ctx := getContext()
+	x := ctx.IntConst("x")
+	solver := NewSolver(ctx)
+	defer solver.Close()
+	solver.Add(Eq(Add(x, ctx.IntVal(2)), ctx.IntVal(-40)))
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+	defer model.Close()
+	value := model.Eval(x, true)
+	if n, err := value.Int64(); n != -42 || err != nil {
+		t.Error("Expected -42, got", n, err)
+	}
+	if n, err := value.BigInt(); err != nil || n.Cmp(big.NewInt(-42)) != 0 {
+		t.Error("Expected -42, got", n, err)
+	}
+	if f, err := value.Float64(); f != -42 || err != nil {
+		t.Error("Expected -42, got", f, err)
+	}
+	if n, err := value.Uint64(); err == nil {
+		t.Error("Expected overflow error, got", n)
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 64)
+	if n, err := ctx.BigIntVal(huge).BigInt(); err != nil || n.Cmp(huge) != 0 {
+		t.Error("Expected", huge, "got", n, err)
+	}
+	if n, err := ctx.BigIntVal(huge).Int64(); err == nil {
+		t.Error("Expected overflow error, got", n)
+	}
+	if n, err := ctx.Uint64Val(math.MaxUint64).Uint64(); n != math.MaxUint64 || err != nil {
+		t.Error("Expected", uint64(math.MaxUint64), "got", n, err)
+	}
+	if n, err := ctx.RealVal(big.NewRat(1, 2)).Int64(); err == nil {
+		t.Error("Expected error for real numeral, got", n)
+	}
+	if n, err := x.Int64(); err == nil {
+		t.Error("Expected error for constant, got", n)
+	}
+	if ctx.LastError == nil || ctx.LastError.Code != InvalidArg {
+		t.Error("Expected", InvalidArg, "got", ctx.LastError)
+	}
+}
+
+func TestRealValues(t *testing.T) {
+	ctx := getContext()
+	if f, err := ctx.RealVal(big.NewRat(-5, 4)).Float64(); f != -1.25 || err != nil {
+		t.Error("Expected -1.25, got", f, err)
+	}
+	if r, err := ctx.RealVal(big.NewRat(1, 3)).Rat(); err != nil || r.Cmp(big.NewRat(1, 3)) != 0 {
+		t.Error("Expected 1/3, got", r, err)
+	}
+	huge := ctx.Numeral("1"+string(bytes.Repeat([]byte("0"), 400)), ctx.RealSort())
+	if f, err := huge.Float64(); err == nil {
+		t.Error("Expected overflow error, got", f)
+	}
+	if f, err := ctx.BoolVal(true).Float64(); err == nil {
+		t.Error("Expected error for Boolean, got", f)
+	}
+}
+
+func TestBoolValues(t *testing.T) {
+	ctx := getContext()
+	if b, err := ctx.BoolVal(true).Bool(); !b || err != nil {
+		t.Error("Expected true, got", b, err)
+	}
+	if b, err := ctx.BoolVal(false).Bool(); b || err != nil {
+		t.Error("Expected false, got", b, err)
+	}
+	if b, err := ctx.BoolConst("p").Bool(); err == nil {
+		t.Error("Expected error for constant, got", b)
+	}
+	if b, err := ctx.IntVal(1).Bool(); err == nil {
+		t.Error("Expected error for integer, got", b)
+	}
+}
+
+func TestFloatingPointValues(t *testing.T) {
+	ctx := getContext()
+	sort := ctx.FPSort(11, 53)
+	if f, err := ctx.FPVal(-0.375, sort).Float64(); f != -0.375 || err != nil {
+		t.Error("Expected -0.375, got", f, err)
+	}
+	if f, err := ctx.FPInf(sort, true).Float64(); !math.IsInf(f, -1) || err != nil {
+		t.Error("Expected -Inf, got", f, err)
+	}
+	for _, test := range []struct {
+		value    *Expr
+		expected float64
+	}{
+		{ctx.FPVal(1e300, ctx.Float128Sort()), 1e300},
+		{ctx.FPVal(-1e-310, ctx.Float128Sort()), -1e-310},
+		{ctx.Float32Val(0.1), float64(float32(0.1))},
+		{ctx.Float32Val(math.SmallestNonzeroFloat32), math.SmallestNonzeroFloat32},
+		{ctx.FPVal(-65504, ctx.Float16Sort()), -65504},
+		{ctx.FPInf(ctx.Float16Sort(), false), math.Inf(1)},
+	} {
+		if f, err := test.value.Float64(); f != test.expected || err != nil {
+			t.Error("Expected", test.expected, "got", f, err)
+		}
+	}
+	huge := FPMul(ctx.RoundNearestTiesToEven(), ctx.FPVal(1e300, ctx.Float128Sort()), ctx.FPVal(1e300, ctx.Float128Sort()))
+	solver := NewSolver(ctx)
+	defer solver.Close()
+	x := ctx.Constant("x", ctx.Float128Sort())
+	solver.Add(Eq(x, huge))
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+	defer model.Close()
+	if f, err := model.Eval(x, true).Float64(); err == nil || !strings.Contains(err.Error(), "overflows float64") {
+		t.Error("Expected overflow error, got", f, err)
+	}
+	if f, err := x.Float64(); err == nil || !strings.Contains(err.Error(), "is not a numeral") {
+		t.Error("Expected error for constant, got", f, err)
+	}
+	if f, err := ctx.FPVal(0.1, sort).Int64(); err == nil {
+		t.Error("Expected error for floating-point numeral, got", f)
+	}
+}
+
+func TestBitVectorValues(t *testing.T) {
+	ctx := getContext()
+	x := ctx.BVConst("x", 12)
+	solver := NewSolver(ctx)
+	defer solver.Close()
+	solver.Add(Eq(BVNot(x), ctx.BVUintVal(0x123, 12)))
+	if result, err := solver.Check(); result != LTrue || err != nil {
+		t.Fatal("Expected", LTrue, "got", result, err)
+	}
+	model := solver.GetModel()
+	defer model.Close()
+	value := model.Eval(x, true)
+	if n, err := value.Uint64(); n != 0xedc || err != nil {
+		t.Errorf("Expected 0xedc, got %#x %v", n, err)
+	}
+	if b, err := value.Bytes(); !bytes.Equal(b, []byte{0x0e, 0xdc}) || err != nil {
+		t.Errorf("Expected [0e dc], got %x %v", b, err)
+	}
+	if b, err := ctx.BVUintVal(1, 1).Bytes(); !bytes.Equal(b, []byte{1}) || err != nil {
+		t.Errorf("Expected [01], got %x %v", b, err)
+	}
+	if b, err := ctx.IntVal(7).Bytes(); err == nil {
+		t.Errorf("Expected error for integer, got %x", b)
+	}
+}
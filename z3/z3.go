@@ -5,9 +5,14 @@ package z3
 // #include <z3.h>
 import "C"
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"runtime"
 	"strconv"
+	"strings"
+	"sync"
 	"unsafe"
 )
 
@@ -16,36 +21,53 @@ import (
 
 // Config contains Z3 configuration parameters.
 type Config struct {
-	z3val C.Z3_config
+	z3val  C.Z3_config
+	closed bool
 }
 
 // SetParamString sets a configuration parameter using a string value
-func (config *Config) SetParamString(id, value string) {
+func (config *Config) SetParamString(id, value string) error {
+	if config.closed {
+		return &Error{InvalidUsage, "config is closed"}
+	}
 	cID, cValue := C.CString(id), C.CString(value)
 	defer func() {
 		C.free(unsafe.Pointer(cID))
 		C.free(unsafe.Pointer(cValue))
 	}()
 	C.Z3_set_param_value(config.z3val, cID, cValue)
+	return nil
 }
 
 // SetParamInt sets a configuration parameter using an int value
-func (config *Config) SetParamInt(id string, value int) {
-	config.SetParamString(id, strconv.FormatInt(int64(value), 10))
+func (config *Config) SetParamInt(id string, value int) error {
+	return config.SetParamString(id, strconv.FormatInt(int64(value), 10))
 }
 
 // SetParamBool sets a configuration parameter using a bool value
-func (config *Config) SetParamBool(id string, value bool) {
-	config.SetParamString(id, strconv.FormatBool(value))
+func (config *Config) SetParamBool(id string, value bool) error {
+	return config.SetParamString(id, strconv.FormatBool(value))
+}
+
+// Close deletes the configuration. Contexts keep their own copy of the
+// parameters, so the configuration may be closed right after NewContext.
+// Calling Close more than once is a no-op.
+func (config *Config) Close() error {
+	if !config.closed {
+		runtime.SetFinalizer(config, nil)
+		config.finalize()
+	}
+	return nil
 }
 
 func (config *Config) finalize() {
+	config.closed = true
 	C.Z3_del_config(config.z3val)
 }
 
 // NewConfig creates a new Z3 configuration object.
 func NewConfig() *Config {
-	config := &Config{C.Z3_mk_config()}
+	config := &Config{z3val: C.Z3_mk_config()}
 	runtime.SetFinalizer(config, (*Config).finalize)
 	return config
 }
@@ -115,6 +137,68 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// Sentinel errors classifying the reasons for unknown results. Use errors.Is
+// to check the error returned along with LUndef against them.
+var (
+	ErrTimeout       = errors.New("timeout")                 // The check ran out of time.
+	ErrCanceled      = errors.New("canceled")                // The check was interrupted.
+	ErrResourceLimit = errors.New("resource limit exceeded") // The check ran out of memory or resources.
+	ErrIncomplete    = errors.New("incomplete")              // The problem is outside the decidable fragment Z3 handles.
+)
+
+// UnknownError is returned along with LUndef by checks that could not decide
+// satisfiability. It matches one of the sentinel errors, if the reason is
+// recognized.
+type UnknownError struct {
+	Reason string // The reason reported by Z3, such as "timeout".
+	cause  error  // The error of the Go context that interrupted the check.
+}
+
+func (e *UnknownError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("unknown: %s (%s)", e.Reason, e.cause)
+	}
+	return "unknown: " + e.Reason
+}
+
+// Unwrap returns context.Canceled or context.DeadlineExceeded for checks
+// interrupted by CheckContext.
+func (e *UnknownError) Unwrap() error {
+	return e.cause
+}
+
+// Is checks whether the reason is classified as the sentinel target.
+func (e *UnknownError) Is(target error) bool {
+	switch e.cause {
+	case context.DeadlineExceeded:
+		return target == ErrTimeout
+	case context.Canceled:
+		return target == ErrCanceled
+	}
+	reason := strings.ToLower(e.Reason)
+	switch target {
+	case ErrTimeout:
+		return strings.Contains(reason, "timeout")
+	case ErrCanceled:
+		return strings.Contains(reason, "cancel") || strings.Contains(reason, "interrupt")
+	case ErrResourceLimit:
+		return strings.Contains(reason, "resource") || strings.Contains(reason, "memory") ||
+			strings.Contains(reason, "memout")
+	case ErrIncomplete:
+		return strings.Contains(reason, "incomplete")
+	}
+	return false
+}
+
+// unknownError returns an UnknownError for the reason given by the reason
+// function if the check returned LUndef without failing, and err otherwise.
+func unknownError(result LiftedBool, err error, reason func() string) error {
+	if result == LUndef && err == nil {
+		return &UnknownError{Reason: reason()}
+	}
+	return err
+}
+
 // -----------------------------------------------------------------------------
 // AST constants
 
@@ -206,19 +290,169 @@ type (
 	Context struct {
 		z3val     C.Z3_context
 		LastError *Error
+		err       *Error // First error since creation or ClearErr
+
+		mu      sync.Mutex
+		pending []func() // Reference releases queued by finalizers
+		refs    int      // Live ASTs, solvers and models created from the context
+		closed  bool
+		deleted bool
 	}
 )
 
-// NewContext creates a new Z3 context.
+// contextDeleted is called after the Z3 context of ctx has been deleted, so
+// that tests can check when contexts are deleted.
+var contextDeleted = func(ctx *Context) {}
+
+// NewContext creates a new Z3 context. It returns nil if config is closed.
 func NewContext(config *Config) *Context {
-	ctx := &Context{C.Z3_mk_context_rc(config.z3val), nil}
+	if config.closed {
+		return nil
+	}
+	ctx := &Context{z3val: C.Z3_mk_context_rc(config.z3val)}
 	C.Z3_set_error_handler(ctx.z3val, nil)
 	runtime.SetFinalizer(ctx, (*Context).finalize)
 	return ctx
 }
 
+// Close marks the context as closed, after which constructors and operators
+// creating new sorts, expressions and other objects from it fail with an
+// InvalidUsage error. Objects created before Close, such as solvers and their
+// models, remain usable. The underlying Z3 context is deleted once every object
+// created from it has been released: right away if they were all closed or
+// collected, and otherwise once the context itself is garbage collected, since
+// objects collected after Close are only released from the context finalizer.
+// Calling Close more than once is a no-op.
+func (ctx *Context) Close() error {
+	if ctx.closed {
+		return nil
+	}
+	ctx.closed = true
+	ctx.releasePending()
+	ctx.deleteIfUnused()
+	return nil
+}
+
 func (ctx *Context) finalize() {
-	C.Z3_del_context(ctx.z3val)
+	ctx.closed = true
+	ctx.releasePending()
+	ctx.deleteIfUnused()
+}
+
+func (ctx *Context) deleteIfUnused() {
+	ctx.mu.Lock()
+	unused := ctx.closed && !ctx.deleted && ctx.refs == 0
+	if unused {
+		ctx.deleted = true
+	}
+	ctx.mu.Unlock()
+	if unused {
+		runtime.SetFinalizer(ctx, nil)
+		C.Z3_del_context(ctx.z3val)
+		contextDeleted(ctx)
+	}
+}
+
+// checkOpen returns an InvalidUsage error if the context was closed.
+func (ctx *Context) checkOpen() error {
+	if ctx.closed {
+		return ctx.usageError("context is closed")
+	}
+	return nil
+}
+
+func (ctx *Context) usageError(message string) error {
+	return ctx.setError(&Error{InvalidUsage, message})
+}
+
+// checkFuncDecls records and returns an InvalidArg error if any of the given
+// function declarations is nil or belongs to a different context.
+func (ctx *Context) checkFuncDecls(decls ...*FuncDecl) error {
+	for i, decl := range decls {
+		if decl == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil function declaration operand at position %d", i)})
+		}
+		if decl.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("function declaration operand at position %d belongs to a different context", i)})
+		}
+	}
+	return nil
+}
+
+// checkPatterns records and returns an InvalidArg error if any of the given
+// patterns is nil or belongs to a different context.
+func (ctx *Context) checkPatterns(patterns ...*Pattern) error {
+	for i, pattern := range patterns {
+		if pattern == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil pattern operand at position %d", i)})
+		}
+		if pattern.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("pattern operand at position %d belongs to a different context", i)})
+		}
+	}
+	return nil
+}
+
+// checkSorts records and returns an InvalidArg error if any of the given sorts
+// is nil or belongs to a different context.
+func (ctx *Context) checkSorts(sorts ...*Sort) error {
+	for i, sort := range sorts {
+		if sort == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil sort operand at position %d", i)})
+		}
+		if sort.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("sort operand at position %d belongs to a different context", i)})
+		}
+	}
+	return nil
+}
+
+// acquire records a new object holding a reference into the context. It also
+// runs the releases queued in the meantime.
+func (ctx *Context) acquire() {
+	ctx.mu.Lock()
+	ctx.refs++
+	ctx.mu.Unlock()
+	ctx.releasePending()
+}
+
+// release drops the reference of an object created from the context, deleting
+// the context if it was closed and this was its last object.
+func (ctx *Context) release(release func()) {
+	release()
+	ctx.mu.Lock()
+	ctx.refs--
+	ctx.mu.Unlock()
+	ctx.deleteIfUnused()
+}
+
+// queueRelease schedules a reference release for the next releasePending
+// call. Finalizers run on their own goroutine, while a Z3 context must only
+// be used by one thread at a time, so they cannot call into Z3 directly. The
+// release must capture the Z3 context rather than ctx itself, since a queued
+// reference back to ctx would keep it from ever being finalized.
+func (ctx *Context) queueRelease(release func()) {
+	ctx.mu.Lock()
+	ctx.pending = append(ctx.pending, release)
+	ctx.mu.Unlock()
+}
+
+// releasePending runs the queued reference releases. It must only be called
+// from the goroutine currently using the context.
+func (ctx *Context) releasePending() {
+	ctx.mu.Lock()
+	pending := ctx.pending
+	ctx.pending = nil
+	ctx.mu.Unlock()
+	for _, release := range pending {
+		ctx.release(release)
+	}
 }
 
 func (ctx *Context) getError() error {
@@ -228,8 +462,70 @@ func (ctx *Context) getError() error {
 		return nil
 	}
 	message := C.GoString(C.Z3_get_error_msg_ex(ctx.z3val, C.Z3_error_code(ec)))
-	ctx.LastError = &Error{ec, message}
-	return ctx.LastError
+	return ctx.setError(&Error{ec, message})
+}
+
+// setError records err as the last error of the context, and as the sticky
+// error returned by Err if none was recorded yet.
+func (ctx *Context) setError(err *Error) error {
+	ctx.LastError = err
+	if ctx.err == nil {
+		ctx.err = err
+	}
+	return err
+}
+
+// Err returns the first error encountered by an operation on the context since
+// it was created or since the last ClearErr call. Sort and expression
+// constructors return nil on failure, so a whole sequence of them can be
+// checked at once, similarly to bufio.Scanner. An operator whose operands are
+// all nil has no context to record an error in, so it only returns nil; the
+// failure that produced the operands was recorded already.
+func (ctx *Context) Err() error {
+	if ctx.err == nil {
+		return nil
+	}
+	return ctx.err
+}
+
+// ClearErr resets the error returned by Err.
+func (ctx *Context) ClearErr() {
+	ctx.err = nil
+}
+
+// operandContext returns the context of the given expressions, or nil if there
+// are none or they are not valid operands. Nil operands typically result from
+// an earlier failed constructor, so an InvalidArg error is recorded in the
+// context instead of crashing.
+func operandContext(operands ...*Expr) *Context {
+	for _, expr := range operands {
+		if expr != nil {
+			if err := expr.ctx.checkOpen(); err != nil {
+				return nil
+			}
+			if err := expr.ctx.checkOperands(operands...); err != nil {
+				return nil
+			}
+			return expr.ctx
+		}
+	}
+	return nil
+}
+
+// checkOperands records and returns an InvalidArg error if any of the given
+// expressions is nil or belongs to a different context.
+func (ctx *Context) checkOperands(operands ...*Expr) error {
+	for i, expr := range operands {
+		if expr == nil {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("nil expression operand at position %d", i)})
+		}
+		if expr.ctx != ctx {
+			return ctx.setError(&Error{InvalidArg,
+				fmt.Sprintf("expression operand at position %d belongs to a different context", i)})
+		}
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -241,6 +537,9 @@ type Symbol struct {
 }
 
 func (ctx *Context) NewStringSymbol(value string) *Symbol {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(cValue))
 
@@ -251,7 +550,29 @@ func (ctx *Context) NewStringSymbol(value string) *Symbol {
 	return &Symbol{z3sym, ctx}
 }
 
+// String returns the name of the symbol, or the empty string if the context
+// is closed.
+func (sym *Symbol) String() string {
+	if err := sym.ctx.checkOpen(); err != nil {
+		return ""
+	}
+	return symbolString(sym.ctx, sym.z3val)
+}
+
+// symbolString returns the name of a symbol held by an object that keeps the
+// context alive, such as a sort or a function declaration, so it may be used
+// after the context is closed.
+func symbolString(ctx *Context, z3sym C.Z3_symbol) string {
+	if C.Z3_get_symbol_kind(ctx.z3val, z3sym) == C.Z3_INT_SYMBOL {
+		return strconv.Itoa(int(C.Z3_get_symbol_int(ctx.z3val, z3sym)))
+	}
+	return C.GoString(C.Z3_get_symbol_string(ctx.z3val, z3sym))
+}
+
 func (ctx *Context) NewIntSymbol(value int) *Symbol {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3sym, err := C.Z3_mk_int_symbol(ctx.z3val, C.int(value)), ctx.getError()
 	if err != nil {
 		return nil
@@ -275,13 +596,34 @@ func (ast *AST) String() string {
 	return C.GoString(C.Z3_ast_to_string(ast.ctx.z3val, ast.z3val))
 }
 
+// initialize takes a reference to the wrapped AST and arranges for it to be
+// released once the wrapper is garbage collected. AST must be the first field
+// of every wrapper type, so that ast points to the start of the allocation.
+// Since the wrapper references its context, the context finalizer is
+// guaranteed to run after the AST finalizer.
 func (ast *AST) initialize() {
 	C.Z3_inc_ref(ast.ctx.z3val, ast.z3val)
-	// TODO: Add a finalizer
+	ast.ctx.acquire()
+	runtime.SetFinalizer(ast, (*AST).finalize)
 }
 
 func (ast *AST) finalize() {
-	C.Z3_dec_ref(ast.ctx.z3val, ast.z3val)
+	z3ctx, z3val := ast.ctx.z3val, ast.z3val
+	ast.ctx.queueRelease(func() {
+		C.Z3_dec_ref(z3ctx, z3val)
+	})
+}
+
+// newExprs wraps the elements of an AST vector returned by Z3 and releases the
+// vector.
+func (ctx *Context) newExprs(v C.Z3_ast_vector) []*Expr {
+	C.Z3_ast_vector_inc_ref(ctx.z3val, v)
+	defer C.Z3_ast_vector_dec_ref(ctx.z3val, v)
+	exprs := make([]*Expr, C.Z3_ast_vector_size(ctx.z3val, v))
+	for i := range exprs {
+		exprs[i] = ctx.newExpr(C.Z3_ast_vector_get(ctx.z3val, v, C.uint(i)))
+	}
+	return exprs
 }
 
 // -----------------------------------------------------------------------------
@@ -299,6 +641,10 @@ func (sort *Sort) SortKind() SortKind {
 	return SortKind(C.Z3_get_sort_kind(sort.ctx.z3val, sort.z3sort()))
 }
 
+func (sort *Sort) Name() string {
+	return symbolString(sort.ctx, C.Z3_get_sort_name(sort.ctx.z3val, sort.z3sort()))
+}
+
 func (sort *Sort) BVSize() uint {
 	z3size, err := C.Z3_get_bv_sort_size(sort.ctx.z3val, sort.z3sort()), sort.ctx.getError()
 	if err != nil {
@@ -330,7 +676,24 @@ func (ctx *Context) newSort(z3sort C.Z3_sort) *Sort {
 	return sort
 }
 
+// UninterpretedSort returns the uninterpreted sort with the given name. Its
+// values are only constrained by the formulas they appear in.
+func (ctx *Context) UninterpretedSort(name string) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	nameSym := ctx.NewStringSymbol(name)
+	z3sort, err := C.Z3_mk_uninterpreted_sort(ctx.z3val, nameSym.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
 func (ctx *Context) BoolSort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3sort, err := C.Z3_mk_bool_sort(ctx.z3val), ctx.getError()
 	if err != nil {
 		return nil
@@ -339,6 +702,9 @@ func (ctx *Context) BoolSort() *Sort {
 }
 
 func (ctx *Context) IntSort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3sort, err := C.Z3_mk_int_sort(ctx.z3val), ctx.getError()
 	if err != nil {
 		return nil
@@ -346,7 +712,21 @@ func (ctx *Context) IntSort() *Sort {
 	return ctx.newSort(z3sort)
 }
 
+func (ctx *Context) RealSort() *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3sort, err := C.Z3_mk_real_sort(ctx.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
 func (ctx *Context) BVSort(size uint) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3sort, err := C.Z3_mk_bv_sort(ctx.z3val, C.uint(size)), ctx.getError()
 	if err != nil {
 		return nil
@@ -354,7 +734,42 @@ func (ctx *Context) BVSort(size uint) *Sort {
 	return ctx.newSort(z3sort)
 }
 
+// ArraySortN returns the sort of multi-dimensional arrays, indexed by tuples
+// of the given domain sorts.
+func (ctx *Context) ArraySortN(d []*Sort, r *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(append(d[:len(d):len(d)], r)...); err != nil {
+		return nil
+	}
+	if len(d) == 0 {
+		ctx.setError(&Error{InvalidArg, "array sort without domain"})
+		return nil
+	}
+	sorts := extractSorts(d)
+	z3sort, err := C.Z3_mk_array_sort_n(ctx.z3val, C.uint(len(sorts)), &sorts[0], r.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newSort(z3sort)
+}
+
+func extractSorts(s []*Sort) (sorts []C.Z3_sort) {
+	sorts = make([]C.Z3_sort, len(s))
+	for i, sort := range s {
+		sorts[i] = sort.z3sort()
+	}
+	return
+}
+
 func (ctx *Context) ArraySort(d *Sort, r *Sort) *Sort {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(d, r); err != nil {
+		return nil
+	}
 	z3sort, err := C.Z3_mk_array_sort(ctx.z3val, d.z3sort(), r.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
@@ -377,6 +792,31 @@ func (expr *Expr) Sort() *Sort {
 	return expr.ctx.newSort(z3sort)
 }
 
+// IsNumeral checks whether the expression is an integer, real or bit-vector
+// numeral.
+func (expr *Expr) IsNumeral() bool {
+	return C.Z3_is_numeral_ast(expr.ctx.z3val, expr.z3val) == C.Z3_TRUE
+}
+
+// Rat returns the exact value of an integer or real numeral, such as those
+// obtained from Model.Eval.
+func (expr *Expr) Rat() (*big.Rat, error) {
+	if !expr.IsNumeral() {
+		return nil, expr.ctx.setError(&Error{InvalidArg,
+			fmt.Sprintf("%s is not a numeral", expr)})
+	}
+	numeral := C.GoString(C.Z3_get_numeral_string(expr.ctx.z3val, expr.z3val))
+	if err := expr.ctx.getError(); err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(numeral)
+	if !ok {
+		return nil, expr.ctx.setError(&Error{InvalidArg,
+			fmt.Sprintf("cannot parse numeral %q", numeral)})
+	}
+	return r, nil
+}
+
 func (ctx *Context) newExpr(z3ast C.Z3_ast) *Expr {
 	expr := &Expr{AST{z3ast, ctx}}
 	expr.initialize()
@@ -384,6 +824,12 @@ func (ctx *Context) newExpr(z3ast C.Z3_ast) *Expr {
 }
 
 func (ctx *Context) Constant(name string, sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
 	nameSym := ctx.NewStringSymbol(name)
 	z3ast, err := C.Z3_mk_const(ctx.z3val, nameSym.z3val, sort.z3sort()), ctx.getError()
 	if err != nil {
@@ -392,6 +838,25 @@ func (ctx *Context) Constant(name string, sort *Sort) *Expr {
 	return ctx.newExpr(z3ast)
 }
 
+// FreshConstant returns a constant of the given sort whose name, starting with
+// prefix, is guaranteed not to clash with other constants.
+func (ctx *Context) FreshConstant(prefix string, sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	cPrefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+
+	z3ast, err := C.Z3_mk_fresh_const(ctx.z3val, cPrefix, sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
 func (ctx *Context) BVConst(name string, size uint) *Expr {
 	return ctx.Constant(name, ctx.BVSort(size))
 }
@@ -404,7 +869,14 @@ func (ctx *Context) IntConst(name string) *Expr {
 	return ctx.Constant(name, ctx.IntSort())
 }
 
+func (ctx *Context) RealConst(name string) *Expr {
+	return ctx.Constant(name, ctx.RealSort())
+}
+
 func (ctx *Context) BoolVal(b bool) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	var z3ast C.Z3_ast
 	var err error
 	if b {
@@ -419,6 +891,9 @@ func (ctx *Context) BoolVal(b bool) *Expr {
 }
 
 func (ctx *Context) IntVal(n int) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3ast, err := C.Z3_mk_int(ctx.z3val, C.int(n), ctx.IntSort().z3sort()), ctx.getError()
 	if err != nil {
 		return nil
@@ -427,6 +902,9 @@ func (ctx *Context) IntVal(n int) *Expr {
 }
 
 func (ctx *Context) UintVal(n uint) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	z3ast, err := C.Z3_mk_unsigned_int(ctx.z3val, C.uint(n), ctx.IntSort().z3sort()), ctx.getError()
 	if err != nil {
 		return nil
@@ -434,138 +912,997 @@ func (ctx *Context) UintVal(n uint) *Expr {
 	return ctx.newExpr(z3ast)
 }
 
-func (ctx *Context) BVIntVal(n int, size uint) *Expr {
-	z3ast, err := C.Z3_mk_int(ctx.z3val, C.int(n), ctx.BVSort(size).z3sort()), ctx.getError()
+func (ctx *Context) Int64Val(n int64) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_int64(ctx.z3val, C.int64_t(n), ctx.IntSort().z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
 	return ctx.newExpr(z3ast)
 }
 
-func (ctx *Context) BVUintVal(n uint, size uint) *Expr {
-	z3ast, err := C.Z3_mk_unsigned_int(ctx.z3val, C.uint(n), ctx.BVSort(size).z3sort()), ctx.getError()
+func (ctx *Context) Uint64Val(n uint64) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_unsigned_int64(ctx.z3val, C.uint64_t(n), ctx.IntSort().z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
 	return ctx.newExpr(z3ast)
 }
 
-// -----------------------------------------------------------------------------
-// Operations
-
-// Array operations
+// BigIntVal returns the integer numeral n.
+func (ctx *Context) BigIntVal(n *big.Int) *Expr {
+	if n == nil {
+		ctx.setError(&Error{InvalidArg, "nil numeral"})
+		return nil
+	}
+	return ctx.Numeral(n.String(), ctx.IntSort())
+}
 
-func Store(a, i, v *Expr) *Expr {
-	z3ast, err := C.Z3_mk_store(a.ctx.z3val, a.z3val, i.z3val, v.z3val), a.ctx.getError()
-	if err != nil {
+// RealVal returns the exact rational numeral r.
+func (ctx *Context) RealVal(r *big.Rat) *Expr {
+	if r == nil {
+		ctx.setError(&Error{InvalidArg, "nil numeral"})
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return ctx.Numeral(r.RatString(), ctx.RealSort())
 }
 
-// Boolean operators
+// Numeral returns a numeral of the given sort from its string representation,
+// which is either an integer, a decimal such as "-1.25", or a fraction such as
+// "3/4" for reals.
+func (ctx *Context) Numeral(value string, sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
 
-func Not(a *Expr) *Expr {
-	z3ast, err := C.Z3_mk_not(a.ctx.z3val, a.z3val), a.ctx.getError()
+	z3ast, err := C.Z3_mk_numeral(ctx.z3val, cValue, sort.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return ctx.newExpr(z3ast)
 }
 
-func extractASTs(e []*Expr) (asts []C.Z3_ast) {
-	asts = make([]C.Z3_ast, len(e))
-	for i, expr := range e {
-		asts[i] = expr.z3val
+func (ctx *Context) BVIntVal(n int, size uint) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
 	}
-	return
-}
-
-func And(e ...*Expr) *Expr {
-	asts := extractASTs(e)
-	z3ast, err := C.Z3_mk_and(e[0].ctx.z3val, C.uint(len(asts)), &asts[0]), e[0].ctx.getError()
+	sort := ctx.BVSort(size)
+	if sort == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_int(ctx.z3val, C.int(n), sort.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return e[0].ctx.newExpr(z3ast)
+	return ctx.newExpr(z3ast)
 }
 
-func Or(e ...*Expr) *Expr {
-	asts := extractASTs(e)
-	z3ast, err := C.Z3_mk_or(e[0].ctx.z3val, C.uint(len(asts)), &asts[0]), e[0].ctx.getError()
+func (ctx *Context) BVUintVal(n uint, size uint) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	sort := ctx.BVSort(size)
+	if sort == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_unsigned_int(ctx.z3val, C.uint(n), sort.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return e[0].ctx.newExpr(z3ast)
+	return ctx.newExpr(z3ast)
 }
 
-// Arithmetic operators
+// -----------------------------------------------------------------------------
+// Function declarations
 
-// Comparison operators
+type FuncDecl struct {
+	AST
+}
 
-func Eq(a, b *Expr) *Expr {
-	z3ast, err := C.Z3_mk_eq(a.ctx.z3val, a.z3val, b.z3val), a.ctx.getError()
+func (decl *FuncDecl) z3funcdecl() C.Z3_func_decl {
+	return C.Z3_func_decl(unsafe.Pointer(decl.z3val))
+}
+
+func (ctx *Context) newFuncDecl(z3decl C.Z3_func_decl) *FuncDecl {
+	z3ast := C.Z3_ast(unsafe.Pointer(z3decl))
+	decl := &FuncDecl{AST{z3ast, ctx}}
+	decl.initialize()
+	return decl
+}
+
+// FuncDecl declares an uninterpreted function with the given domain and range
+// sorts. A function without arguments is a constant.
+func (ctx *Context) FuncDecl(name string, domain []*Sort, rng *Sort) *FuncDecl {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(append(domain[:len(domain):len(domain)], rng)...); err != nil {
+		return nil
+	}
+	nameSym := ctx.NewStringSymbol(name)
+	z3domain := extractSorts(domain)
+	z3decl, err := C.Z3_mk_func_decl(ctx.z3val, nameSym.z3val,
+		C.uint(len(z3domain)), sortsPtr(z3domain), rng.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return ctx.newFuncDecl(z3decl)
 }
 
-func Distinct(e ...*Expr) *Expr {
-	asts := extractASTs(e)
-	z3ast, err := C.Z3_mk_distinct(e[0].ctx.z3val, C.uint(len(asts)), &asts[0]), e[0].ctx.getError()
-	if err != nil {
+// FreshFuncDecl declares an uninterpreted function whose name, starting with
+// prefix, is guaranteed not to clash with other declarations.
+func (ctx *Context) FreshFuncDecl(prefix string, domain []*Sort, rng *Sort) *FuncDecl {
+	if err := ctx.checkOpen(); err != nil {
 		return nil
 	}
-	return e[0].ctx.newExpr(z3ast)
-}
+	if err := ctx.checkSorts(append(domain[:len(domain):len(domain)], rng)...); err != nil {
+		return nil
+	}
+	cPrefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cPrefix))
 
-func Lt(a, b *Expr) *Expr {
-	z3ast, err := C.Z3_mk_lt(a.ctx.z3val, a.z3val, b.z3val), a.ctx.getError()
+	z3domain := extractSorts(domain)
+	z3decl, err := C.Z3_mk_fresh_func_decl(ctx.z3val, cPrefix,
+		C.uint(len(z3domain)), sortsPtr(z3domain), rng.z3sort()), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return ctx.newFuncDecl(z3decl)
 }
 
-func Le(a, b *Expr) *Expr {
-	z3ast, err := C.Z3_mk_le(a.ctx.z3val, a.z3val, b.z3val), a.ctx.getError()
-	if err != nil {
+func sortsPtr(sorts []C.Z3_sort) *C.Z3_sort {
+	if len(sorts) == 0 {
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return &sorts[0]
 }
 
-func Gt(a, b *Expr) *Expr {
-	z3ast, err := C.Z3_mk_gt(a.ctx.z3val, a.z3val, b.z3val), a.ctx.getError()
+// Apply returns the application of the function to the given arguments.
+func (decl *FuncDecl) Apply(args ...*Expr) *Expr {
+	ctx := decl.ctx
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkOperands(args...); err != nil {
+		return nil
+	}
+	asts := extractASTs(args)
+	z3ast, err := C.Z3_mk_app(ctx.z3val, decl.z3funcdecl(), C.uint(len(asts)), astsPtr(asts)), ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return a.ctx.newExpr(z3ast)
+	return ctx.newExpr(z3ast)
 }
 
-func Ge(a, b *Expr) *Expr {
-	z3ast, err := C.Z3_mk_ge(a.ctx.z3val, a.z3val, b.z3val), a.ctx.getError()
-	if err != nil {
-		return nil
+func (decl *FuncDecl) Name() string {
+	return symbolString(decl.ctx, C.Z3_get_decl_name(decl.ctx.z3val, decl.z3funcdecl()))
+}
+
+func (decl *FuncDecl) Arity() uint {
+	return uint(C.Z3_get_arity(decl.ctx.z3val, decl.z3funcdecl()))
+}
+
+// Domain returns the sorts of the arguments of the function.
+func (decl *FuncDecl) Domain() []*Sort {
+	domain := make([]*Sort, decl.Arity())
+	for i := range domain {
+		domain[i] = decl.ctx.newSort(C.Z3_get_domain(decl.ctx.z3val, decl.z3funcdecl(), C.uint(i)))
 	}
-	return a.ctx.newExpr(z3ast)
+	return domain
 }
 
-// ITE
+// Range returns the sort of the result of the function.
+func (decl *FuncDecl) Range() *Sort {
+	return decl.ctx.newSort(C.Z3_get_range(decl.ctx.z3val, decl.z3funcdecl()))
+}
 
-func Ite(c, t, e *Expr) *Expr {
-	z3ast, err := C.Z3_mk_ite(c.ctx.z3val, c.z3val, t.z3val, e.z3val), c.ctx.getError()
+// Decl returns the function declaration of an application expression, such as
+// the + operator of Add(x, y).
+func (expr *Expr) Decl() *FuncDecl {
+	if C.Z3_is_app(expr.ctx.z3val, expr.z3val) != C.Z3_TRUE {
+		expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not an application", expr)})
+		return nil
+	}
+	z3app := C.Z3_to_app(expr.ctx.z3val, expr.z3val)
+	z3decl, err := C.Z3_get_app_decl(expr.ctx.z3val, z3app), expr.ctx.getError()
 	if err != nil {
 		return nil
 	}
-	return c.ctx.newExpr(z3ast)
+	return expr.ctx.newFuncDecl(z3decl)
 }
 
-// Quantifiers
-
 // -----------------------------------------------------------------------------
-// Solvers
+// Operations
+
+// Array operations
+
+// Select returns the value of array a at index i.
+func Select(a, i *Expr) *Expr {
+	ctx := operandContext(a, i)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_select(ctx.z3val, a.z3val, i.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// SelectN returns the value of the multi-dimensional array a at the given
+// indices.
+func SelectN(a *Expr, i ...*Expr) *Expr {
+	ctx := operandContext(append([]*Expr{a}, i...)...)
+	if ctx == nil {
+		return nil
+	}
+	if len(i) == 0 {
+		ctx.setError(&Error{InvalidArg, "array select without indices"})
+		return nil
+	}
+	idxs := extractASTs(i)
+	z3ast, err := C.Z3_mk_select_n(ctx.z3val, a.z3val, C.uint(len(idxs)), &idxs[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Store returns array a updated with value v at index i.
+func Store(a, i, v *Expr) *Expr {
+	ctx := operandContext(a, i, v)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_store(ctx.z3val, a.z3val, i.z3val, v.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// StoreN returns the multi-dimensional array a updated with value v at the
+// given indices.
+func StoreN(a *Expr, i []*Expr, v *Expr) *Expr {
+	ctx := operandContext(append([]*Expr{a, v}, i...)...)
+	if ctx == nil {
+		return nil
+	}
+	if len(i) == 0 {
+		ctx.setError(&Error{InvalidArg, "array store without indices"})
+		return nil
+	}
+	idxs := extractASTs(i)
+	z3ast, err := C.Z3_mk_store_n(ctx.z3val, a.z3val, C.uint(len(idxs)), &idxs[0], v.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ConstArray returns the array over the domain sort d mapping every index to v.
+func ConstArray(d *Sort, v *Expr) *Expr {
+	ctx := operandContext(v)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(d); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_const_array(ctx.z3val, d.z3sort(), v.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Map applies the n-ary function f pointwise to n arrays with the same domain.
+func Map(f *FuncDecl, a ...*Expr) *Expr {
+	ctx := operandContext(a...)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkFuncDecls(f); err != nil {
+		return nil
+	}
+	asts := extractASTs(a)
+	z3ast, err := C.Z3_mk_map(ctx.z3val, f.z3funcdecl(), C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ArrayDefault returns the default value of array a, such as the value of a
+// constant array.
+func ArrayDefault(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_array_default(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ArrayExt returns an index at which arrays a and b differ, if they are not
+// equal.
+func ArrayExt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_array_ext(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// AsArray returns the array whose value at every index is the application of
+// the unary function f to that index.
+func AsArray(f *FuncDecl) *Expr {
+	if f == nil {
+		return nil
+	}
+	ctx := f.ctx
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_as_array(ctx.z3val, f.z3funcdecl()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// IsAsArray checks whether the expression is an as-array term, as found in
+// models for arrays that are interpreted by a function.
+func (expr *Expr) IsAsArray() bool {
+	return C.Z3_is_as_array(expr.ctx.z3val, expr.z3val) == C.Z3_TRUE
+}
+
+// AsArrayFuncDecl returns the function of an as-array term.
+func (expr *Expr) AsArrayFuncDecl() *FuncDecl {
+	if !expr.IsAsArray() {
+		expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not an as-array term", expr)})
+		return nil
+	}
+	z3decl, err := C.Z3_get_as_array_func_decl(expr.ctx.z3val, expr.z3val), expr.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return expr.ctx.newFuncDecl(z3decl)
+}
+
+// Boolean operators
+
+func Not(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_not(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Implies returns the implication a => b.
+func Implies(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_implies(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func extractASTs(e []*Expr) (asts []C.Z3_ast) {
+	asts = make([]C.Z3_ast, len(e))
+	for i, expr := range e {
+		asts[i] = expr.z3val
+	}
+	return
+}
+
+// And returns the conjunction of the given expressions. It fails for an empty
+// list, which has no context; use Context.And in that case.
+func And(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.And(e...)
+}
+
+// And returns the conjunction of the given expressions, or true if there are
+// none.
+func (ctx *Context) And(e ...*Expr) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkOperands(e...); err != nil {
+		return nil
+	}
+	if len(e) == 0 {
+		return ctx.BoolVal(true)
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_and(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Or returns the disjunction of the given expressions. It fails for an empty
+// list, which has no context; use Context.Or in that case.
+func Or(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Or(e...)
+}
+
+// Or returns the disjunction of the given expressions, or false if there are
+// none.
+func (ctx *Context) Or(e ...*Expr) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkOperands(e...); err != nil {
+		return nil
+	}
+	if len(e) == 0 {
+		return ctx.BoolVal(false)
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_or(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Arithmetic operators
+
+// Add returns the sum of one or more integer or real expressions.
+func Add(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_add(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Mul returns the product of one or more integer or real expressions.
+func Mul(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_mul(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Sub subtracts the remaining expressions from the first one.
+func Sub(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_sub(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Neg returns the arithmetic negation of a.
+func Neg(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_unary_minus(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Div returns a / b. Both operands must be either integers, denoting integer
+// division, or reals.
+func Div(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_div(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Mod returns a modulo b, for integer operands.
+func Mod(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_mod(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Rem returns the remainder of a divided by b, for integer operands.
+func Rem(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_rem(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Power returns a raised to the power b.
+func Power(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_power(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ToReal coerces an integer expression to a real.
+func ToReal(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_int2real(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ToInt returns the largest integer not greater than the real expression a.
+func ToInt(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_real2int(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// IsInt checks whether the real expression a has an integer value.
+func IsInt(a *Expr) *Expr {
+	ctx := operandContext(a)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_is_int(ctx.z3val, a.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Comparison operators
+
+func Eq(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_eq(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Distinct states that the given expressions are pairwise distinct. It fails
+// for an empty list, which has no context; use Context.Distinct in that case.
+func Distinct(e ...*Expr) *Expr {
+	ctx := operandContext(e...)
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Distinct(e...)
+}
+
+// Distinct states that the given expressions are pairwise distinct, which
+// trivially holds if there are none.
+func (ctx *Context) Distinct(e ...*Expr) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkOperands(e...); err != nil {
+		return nil
+	}
+	if len(e) == 0 {
+		return ctx.BoolVal(true)
+	}
+	asts := extractASTs(e)
+	z3ast, err := C.Z3_mk_distinct(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func Lt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_lt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func Le(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_le(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func Gt(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_gt(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func Ge(a, b *Expr) *Expr {
+	ctx := operandContext(a, b)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_ge(ctx.z3val, a.z3val, b.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ITE
+
+func Ite(c, t, e *Expr) *Expr {
+	ctx := operandContext(c, t, e)
+	if ctx == nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_ite(ctx.z3val, c.z3val, t.z3val, e.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Quantifiers
+
+type Pattern struct {
+	AST
+}
+
+func (pattern *Pattern) z3pattern() C.Z3_pattern {
+	return C.Z3_pattern(unsafe.Pointer(pattern.z3val))
+}
+
+func (ctx *Context) newPattern(z3pattern C.Z3_pattern) *Pattern {
+	z3ast := C.Z3_ast(unsafe.Pointer(z3pattern))
+	pattern := &Pattern{AST{z3ast, ctx}}
+	pattern.initialize()
+	return pattern
+}
+
+// NewPattern returns a multi-pattern used to instantiate a quantifier when all
+// of the given terms are matched. Each term must contain bound variables of
+// the quantifier, and must not be a bare variable.
+func NewPattern(terms ...*Expr) *Pattern {
+	ctx := operandContext(terms...)
+	if ctx == nil {
+		return nil
+	}
+	asts := extractASTs(terms)
+	z3pattern, err := C.Z3_mk_pattern(ctx.z3val, C.uint(len(asts)), &asts[0]), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newPattern(z3pattern)
+}
+
+// Terms returns the terms of the multi-pattern.
+func (pattern *Pattern) Terms() []*Expr {
+	ctx := pattern.ctx
+	terms := make([]*Expr, C.Z3_get_pattern_num_terms(ctx.z3val, pattern.z3pattern()))
+	for i := range terms {
+		terms[i] = ctx.newExpr(C.Z3_get_pattern(ctx.z3val, pattern.z3pattern(), C.uint(i)))
+	}
+	return terms
+}
+
+// Bound returns the de Bruijn-indexed variable of the given sort, for use in
+// the body of a Quantifier. Index 0 refers to the innermost bound variable.
+func (ctx *Context) Bound(index uint, sort *Sort) *Expr {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sort); err != nil {
+		return nil
+	}
+	z3ast, err := C.Z3_mk_bound(ctx.z3val, C.uint(index), sort.z3sort()), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// ForAll returns the universal quantification of body over the given
+// constants, which become bound variables.
+func ForAll(bound []*Expr, body *Expr, patterns ...*Pattern) *Expr {
+	return QuantifierConst(true, 0, bound, patterns, nil, body)
+}
+
+// Exists returns the existential quantification of body over the given
+// constants, which become bound variables.
+func Exists(bound []*Expr, body *Expr, patterns ...*Pattern) *Expr {
+	return QuantifierConst(false, 0, bound, patterns, nil, body)
+}
+
+// QuantifierConst returns a universal or existential quantifier over the given
+// constants. The weight sets the importance of the quantifier during
+// instantiation, with 0 being the default. Terms in noPatterns are excluded
+// from the patterns Z3 infers when none are given.
+func QuantifierConst(forall bool, weight uint, bound []*Expr, patterns []*Pattern, noPatterns []*Expr, body *Expr) *Expr {
+	ctx := operandContext(append(append([]*Expr{body}, bound...), noPatterns...)...)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkPatterns(patterns...); err != nil {
+		return nil
+	}
+	if len(bound) == 0 {
+		ctx.setError(&Error{InvalidArg, "quantifier without bound variables"})
+		return nil
+	}
+	apps := make([]C.Z3_app, len(bound))
+	for i, expr := range bound {
+		apps[i] = C.Z3_to_app(ctx.z3val, expr.z3val)
+	}
+	z3patterns, z3noPatterns := extractPatterns(patterns), extractASTs(noPatterns)
+	z3ast, err := C.Z3_mk_quantifier_const_ex(ctx.z3val, getZ3Bool(forall), C.uint(weight), nil, nil,
+		C.uint(len(apps)), &apps[0],
+		C.uint(len(z3patterns)), patternsPtr(z3patterns),
+		C.uint(len(z3noPatterns)), astsPtr(z3noPatterns),
+		body.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+// Quantifier returns a universal or existential quantifier over the variables
+// of body created with Context.Bound. The names and sorts are listed from the
+// outermost variable, so the last one has index 0.
+func Quantifier(forall bool, weight uint, names []string, sorts []*Sort, patterns []*Pattern, noPatterns []*Expr, body *Expr) *Expr {
+	ctx := operandContext(append([]*Expr{body}, noPatterns...)...)
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.checkSorts(sorts...); err != nil {
+		return nil
+	}
+	if err := ctx.checkPatterns(patterns...); err != nil {
+		return nil
+	}
+	if len(sorts) == 0 || len(names) != len(sorts) {
+		ctx.setError(&Error{InvalidArg, fmt.Sprintf(
+			"quantifier needs matching bound variable names and sorts, got %d and %d", len(names), len(sorts))})
+		return nil
+	}
+	symbols := make([]C.Z3_symbol, len(names))
+	for i, name := range names {
+		sym := ctx.NewStringSymbol(name)
+		if sym == nil {
+			return nil
+		}
+		symbols[i] = sym.z3val
+	}
+	z3sorts := extractSorts(sorts)
+	z3patterns, z3noPatterns := extractPatterns(patterns), extractASTs(noPatterns)
+	z3ast, err := C.Z3_mk_quantifier_ex(ctx.z3val, getZ3Bool(forall), C.uint(weight), nil, nil,
+		C.uint(len(z3patterns)), patternsPtr(z3patterns),
+		C.uint(len(z3noPatterns)), astsPtr(z3noPatterns),
+		C.uint(len(z3sorts)), &z3sorts[0], &symbols[0],
+		body.z3val), ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return ctx.newExpr(z3ast)
+}
+
+func extractPatterns(p []*Pattern) (patterns []C.Z3_pattern) {
+	patterns = make([]C.Z3_pattern, len(p))
+	for i, pattern := range p {
+		patterns[i] = pattern.z3pattern()
+	}
+	return
+}
+
+func patternsPtr(patterns []C.Z3_pattern) *C.Z3_pattern {
+	if len(patterns) == 0 {
+		return nil
+	}
+	return &patterns[0]
+}
+
+func astsPtr(asts []C.Z3_ast) *C.Z3_ast {
+	if len(asts) == 0 {
+		return nil
+	}
+	return &asts[0]
+}
+
+func (expr *Expr) checkQuantifier() error {
+	if expr.ASTKind() != QuantifierAST {
+		return expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a quantifier", expr)})
+	}
+	return nil
+}
+
+// IsForAll checks whether the expression is a universal quantifier.
+func (expr *Expr) IsForAll() bool {
+	return expr.ASTKind() == QuantifierAST &&
+		C.Z3_is_quantifier_forall(expr.ctx.z3val, expr.z3val) == C.Z3_TRUE
+}
+
+// IsExists checks whether the expression is an existential quantifier.
+func (expr *Expr) IsExists() bool {
+	return expr.ASTKind() == QuantifierAST &&
+		C.Z3_is_quantifier_exists(expr.ctx.z3val, expr.z3val) == C.Z3_TRUE
+}
+
+// BoundIndex returns the de Bruijn index of a bound variable.
+func (expr *Expr) BoundIndex() (uint, error) {
+	if expr.ASTKind() != VarAST {
+		return 0, expr.ctx.setError(&Error{InvalidArg, fmt.Sprintf("%s is not a bound variable", expr)})
+	}
+	return uint(C.Z3_get_index_value(expr.ctx.z3val, expr.z3val)), nil
+}
+
+// QuantifierWeight returns the weight of a quantifier.
+func (expr *Expr) QuantifierWeight() (uint, error) {
+	if err := expr.checkQuantifier(); err != nil {
+		return 0, err
+	}
+	return uint(C.Z3_get_quantifier_weight(expr.ctx.z3val, expr.z3val)), nil
+}
+
+// QuantifierBody returns the body of a quantifier, in which the bound
+// variables appear as de Bruijn-indexed variables.
+func (expr *Expr) QuantifierBody() *Expr {
+	if err := expr.checkQuantifier(); err != nil {
+		return nil
+	}
+	return expr.ctx.newExpr(C.Z3_get_quantifier_body(expr.ctx.z3val, expr.z3val))
+}
+
+// QuantifierBoundNames returns the names of the variables bound by a
+// quantifier, from the outermost one.
+func (expr *Expr) QuantifierBoundNames() []string {
+	if err := expr.checkQuantifier(); err != nil {
+		return nil
+	}
+	ctx := expr.ctx
+	names := make([]string, C.Z3_get_quantifier_num_bound(ctx.z3val, expr.z3val))
+	for i := range names {
+		names[i] = symbolString(ctx, C.Z3_get_quantifier_bound_name(ctx.z3val, expr.z3val, C.uint(i)))
+	}
+	return names
+}
+
+// QuantifierBoundSorts returns the sorts of the variables bound by a
+// quantifier, from the outermost one.
+func (expr *Expr) QuantifierBoundSorts() []*Sort {
+	if err := expr.checkQuantifier(); err != nil {
+		return nil
+	}
+	ctx := expr.ctx
+	sorts := make([]*Sort, C.Z3_get_quantifier_num_bound(ctx.z3val, expr.z3val))
+	for i := range sorts {
+		sorts[i] = ctx.newSort(C.Z3_get_quantifier_bound_sort(ctx.z3val, expr.z3val, C.uint(i)))
+	}
+	return sorts
+}
+
+// QuantifierPatterns returns the patterns of a quantifier.
+func (expr *Expr) QuantifierPatterns() []*Pattern {
+	if err := expr.checkQuantifier(); err != nil {
+		return nil
+	}
+	ctx := expr.ctx
+	patterns := make([]*Pattern, C.Z3_get_quantifier_num_patterns(ctx.z3val, expr.z3val))
+	for i := range patterns {
+		patterns[i] = ctx.newPattern(C.Z3_get_quantifier_pattern_ast(ctx.z3val, expr.z3val, C.uint(i)))
+	}
+	return patterns
+}
+
+// QuantifierNoPatterns returns the terms excluded from the inferred patterns of
+// a quantifier.
+func (expr *Expr) QuantifierNoPatterns() []*Expr {
+	if err := expr.checkQuantifier(); err != nil {
+		return nil
+	}
+	ctx := expr.ctx
+	noPatterns := make([]*Expr, C.Z3_get_quantifier_num_no_patterns(ctx.z3val, expr.z3val))
+	for i := range noPatterns {
+		noPatterns[i] = ctx.newExpr(C.Z3_get_quantifier_no_pattern_ast(ctx.z3val, expr.z3val, C.uint(i)))
+	}
+	return noPatterns
+}
+
+// -----------------------------------------------------------------------------
+// Solvers
 
 type LiftedBool int
 
@@ -590,37 +1927,184 @@ func (lb LiftedBool) String() string {
 
 // Solver encapsulates a Z3 solver instance.
 type Solver struct {
-	z3val C.Z3_solver
-	ctx   *Context
+	z3val  C.Z3_solver
+	ctx    *Context
+	closed bool
+	descrs paramDescrsCache
 }
 
 func (solver *Solver) String() string {
+	if solver.closed {
+		return ""
+	}
 	return C.GoString(C.Z3_solver_to_string(solver.ctx.z3val, solver.z3val))
 }
 
+// Close releases the solver. Calling Close more than once is a no-op.
+func (solver *Solver) Close() error {
+	if !solver.closed {
+		solver.closed = true
+		runtime.SetFinalizer(solver, nil)
+		solver.descrs.close()
+		solver.ctx.releasePending()
+		solver.ctx.release(solver.decRef())
+	}
+	return nil
+}
+
+func (solver *Solver) finalize() {
+	solver.ctx.queueRelease(solver.decRef())
+}
+
+func (solver *Solver) decRef() func() {
+	z3ctx, z3val := solver.ctx.z3val, solver.z3val
+	return func() {
+		C.Z3_solver_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (solver *Solver) checkOpen() error {
+	if solver.closed {
+		return solver.ctx.usageError("solver is closed")
+	}
+	return nil
+}
+
+// ParamDescrs describes the parameters accepted by SetParams.
+func (solver *Solver) ParamDescrs() *ParamDescrs {
+	if err := solver.checkOpen(); err != nil {
+		return nil
+	}
+	return solver.descrs.paramDescrs(solver.ctx, solver.describeParams)
+}
+
+func (solver *Solver) describeParams() C.Z3_param_descrs {
+	return C.Z3_solver_get_param_descrs(solver.ctx.z3val, solver.z3val)
+}
+
+// SetParams configures the solver. Parameters are checked against ParamDescrs
+// by Params.Validate.
+func (solver *Solver) SetParams(params *Params) error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
+	return solver.descrs.setParams(solver.ctx, params, solver.describeParams, func(z3params C.Z3_params) {
+		C.Z3_solver_set_params(solver.ctx.z3val, solver.z3val, z3params)
+	})
+}
+
 func (solver *Solver) Reset() error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
 	C.Z3_solver_reset(solver.ctx.z3val, solver.z3val)
 	return solver.ctx.getError()
 }
 
 func (solver *Solver) Push() error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
 	C.Z3_solver_push(solver.ctx.z3val, solver.z3val)
 	return solver.ctx.getError()
 }
 
 func (solver *Solver) Pop(n uint) error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
 	C.Z3_solver_pop(solver.ctx.z3val, solver.z3val, C.uint(n))
 	return solver.ctx.getError()
 }
 
 func (solver *Solver) Check() (result LiftedBool, err error) {
+	if err = solver.checkOpen(); err != nil {
+		return LUndef, err
+	}
 	result = LiftedBool(C.Z3_solver_check(solver.ctx.z3val, solver.z3val))
-	err = solver.ctx.getError()
+	err = unknownError(result, solver.ctx.getError(), solver.ReasonUnknown)
+	return
+}
+
+// ReasonUnknown returns a brief description of why the last check returned
+// LUndef, such as "timeout" or "incomplete quantifiers".
+func (solver *Solver) ReasonUnknown() string {
+	if solver.closed {
+		return ""
+	}
+	return C.GoString(C.Z3_solver_get_reason_unknown(solver.ctx.z3val, solver.z3val))
+}
+
+// CheckAssumptions checks the assertions together with the given Boolean
+// constants or their negations, which hold only for this check. When the
+// result is LFalse, UnsatCore returns the assumptions responsible.
+func (solver *Solver) CheckAssumptions(assumptions ...*Expr) (result LiftedBool, err error) {
+	if err = solver.checkOpen(); err != nil {
+		return LUndef, err
+	}
+	if err = solver.ctx.checkOperands(assumptions...); err != nil {
+		return LUndef, err
+	}
+	asts := extractASTs(assumptions)
+	result = LiftedBool(C.Z3_solver_check_assumptions(solver.ctx.z3val, solver.z3val,
+		C.uint(len(asts)), astsPtr(asts)))
+	err = unknownError(result, solver.ctx.getError(), solver.ReasonUnknown)
+	return
+}
+
+// CheckContext is like CheckAssumptions, but interrupts the check when goCtx is
+// canceled or its deadline passes. An interrupted check returns LUndef with an
+// UnknownError that matches ErrCanceled or ErrTimeout, and wraps goCtx.Err().
+// The solver remains usable afterwards. Since Z3 interrupts every operation on the
+// solver's context, no other solver of the context should be used meanwhile.
+func (solver *Solver) CheckContext(goCtx context.Context, assumptions ...*Expr) (result LiftedBool, err error) {
+	if err = solver.checkOpen(); err != nil {
+		return LUndef, err
+	}
+	if err := goCtx.Err(); err != nil {
+		return LUndef, &UnknownError{Reason: "canceled", cause: err}
+	}
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-goCtx.Done():
+			C.Z3_interrupt(solver.ctx.z3val)
+		case <-done:
+		}
+	}()
+	result, err = solver.CheckAssumptions(assumptions...)
+	close(done)
+	<-stopped
+	if e, ok := err.(*UnknownError); ok && goCtx.Err() != nil {
+		e.cause = goCtx.Err()
+	}
 	return
 }
 
+// UnsatCore returns a subset of the assumptions of the last check, including
+// the labels of tracked assertions, that is unsatisfiable together with the
+// other assertions. The core is not minimal unless the solver parameter
+// "core.minimize" is set, which trades time for a smaller core.
+func (solver *Solver) UnsatCore() []*Expr {
+	if err := solver.checkOpen(); err != nil {
+		return nil
+	}
+	v, err := C.Z3_solver_get_unsat_core(solver.ctx.z3val, solver.z3val), solver.ctx.getError()
+	if err != nil {
+		return nil
+	}
+	return solver.ctx.newExprs(v)
+}
+
 func (solver *Solver) Add(a ...*Expr) error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
 	for _, expr := range a {
+		if err := solver.ctx.checkOperands(expr); err != nil {
+			return err
+		}
 		C.Z3_solver_assert(solver.ctx.z3val, solver.z3val, expr.z3val)
 		if err := solver.ctx.getError(); err != nil {
 			return err
@@ -629,36 +2113,108 @@ func (solver *Solver) Add(a ...*Expr) error {
 	return nil
 }
 
-// NewSolver creates a new Z3 solver.
-func NewSolver(ctx *Context) *Solver {
-	solver := &Solver{C.Z3_mk_solver(ctx.z3val), ctx}
-	C.Z3_solver_inc_ref(ctx.z3val, solver.z3val)
+// AssertAndTrack asserts a, tracked by the Boolean constant label. The label
+// is assumed by every check and reported by UnsatCore when a takes part in a
+// conflict.
+func (solver *Solver) AssertAndTrack(a, label *Expr) error {
+	if err := solver.checkOpen(); err != nil {
+		return err
+	}
+	if err := solver.ctx.checkOperands(a, label); err != nil {
+		return err
+	}
+	C.Z3_solver_assert_and_track(solver.ctx.z3val, solver.z3val, a.z3val, label.z3val)
+	return solver.ctx.getError()
+}
+
+func (ctx *Context) newSolver(z3solver C.Z3_solver) *Solver {
+	solver := &Solver{z3val: z3solver, ctx: ctx}
+	C.Z3_solver_inc_ref(ctx.z3val, z3solver)
+	ctx.acquire()
+	runtime.SetFinalizer(solver, (*Solver).finalize)
 	return solver
 }
 
-// NewSolverForLogic creates a new Z3 solver for a given logic.
+// NewSolver creates a new Z3 solver. It returns nil if the context is closed.
+func NewSolver(ctx *Context) *Solver {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	return ctx.newSolver(C.Z3_mk_solver(ctx.z3val))
+}
+
+// NewSolverForLogic creates a new Z3 solver for a given logic. It returns nil
+// if the context is closed.
 func NewSolverForLogic(ctx *Context, logic string) *Solver {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
 	sym := ctx.NewStringSymbol(logic)
-	solver := &Solver{C.Z3_mk_solver_for_logic(ctx.z3val, sym.z3val), ctx}
-	C.Z3_solver_inc_ref(ctx.z3val, solver.z3val)
-	return solver
+	return ctx.newSolver(C.Z3_mk_solver_for_logic(ctx.z3val, sym.z3val))
+}
+
+// NewSolverFromTactic creates a new Z3 solver that checks its assertions by
+// applying the tactic t. It returns nil if the context is closed.
+func NewSolverFromTactic(ctx *Context, t *Tactic) *Solver {
+	if err := ctx.checkOpen(); err != nil {
+		return nil
+	}
+	if err := ctx.checkTactics(t); err != nil {
+		return nil
+	}
+	return ctx.newSolver(C.Z3_mk_solver_from_tactic(ctx.z3val, t.z3val))
 }
 
 // -----------------------------------------------------------------------------
 // Models
 
 type Model struct {
-	z3val C.Z3_model
-	ctx   *Context
+	z3val  C.Z3_model
+	ctx    *Context
+	closed bool
 }
 
 func (ctx *Context) newModel(z3model C.Z3_model) (model *Model) {
-	model = &Model{z3model, ctx}
+	model = &Model{z3val: z3model, ctx: ctx}
 	C.Z3_model_inc_ref(ctx.z3val, z3model)
+	ctx.acquire()
+	runtime.SetFinalizer(model, (*Model).finalize)
 	return model
 }
 
+// Close releases the model. Calling Close more than once is a no-op.
+func (model *Model) Close() error {
+	if !model.closed {
+		model.closed = true
+		runtime.SetFinalizer(model, nil)
+		model.ctx.releasePending()
+		model.ctx.release(model.decRef())
+	}
+	return nil
+}
+
+func (model *Model) finalize() {
+	model.ctx.queueRelease(model.decRef())
+}
+
+func (model *Model) decRef() func() {
+	z3ctx, z3val := model.ctx.z3val, model.z3val
+	return func() {
+		C.Z3_model_dec_ref(z3ctx, z3val)
+	}
+}
+
+func (model *Model) checkOpen() error {
+	if model.closed {
+		return model.ctx.usageError("model is closed")
+	}
+	return nil
+}
+
 func (solver *Solver) GetModel() *Model {
+	if err := solver.checkOpen(); err != nil {
+		return nil
+	}
 	z3model, err := C.Z3_solver_get_model(solver.ctx.z3val, solver.z3val), solver.ctx.getError()
 	if err != nil {
 		return nil
@@ -667,6 +2223,9 @@ func (solver *Solver) GetModel() *Model {
 }
 
 func (model *Model) String() string {
+	if model.closed {
+		return ""
+	}
 	return C.GoString(C.Z3_model_to_string(model.ctx.z3val, model.z3val))
 }
 
@@ -679,6 +2238,12 @@ func getZ3Bool(b bool) C.Z3_bool {
 }
 
 func (model *Model) Eval(n *Expr, completion bool) (result *Expr) {
+	if err := model.checkOpen(); err != nil {
+		return nil
+	}
+	if err := model.ctx.checkOperands(n); err != nil {
+		return nil
+	}
 	var z3result C.Z3_ast
 	status := C.Z3_model_eval(model.ctx.z3val, model.z3val, n.z3val,
 		getZ3Bool(completion), &z3result) == C.Z3_TRUE